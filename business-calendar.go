@@ -0,0 +1,307 @@
+package handy
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const businessCalendarMaxStep = 3660
+
+// Holiday is a holiday occurrence in a given year
+type Holiday struct {
+	Name string
+	Date time.Time
+}
+
+// HolidayRule computes the date of a holiday for any year
+type HolidayRule struct {
+	Name string
+	// Date returns the holiday month and day in the given year, and false when the holiday doesn't happen that year
+	Date func(year int) (time.Month, int, bool)
+}
+
+// HolidayFixed returns a rule for a holiday that happens every year at the same day, like christmas
+func HolidayFixed(name string, month time.Month, day int) HolidayRule {
+	return HolidayRule{Name: name, Date: func(year int) (time.Month, int, bool) {
+		return month, day, true
+	}}
+}
+
+// HolidayEaster returns a rule for a holiday relative to the easter sunday, like Good Friday, that's HolidayEaster("Good Friday", -2)
+func HolidayEaster(name string, daysFromEaster int) HolidayRule {
+	return HolidayRule{Name: name, Date: func(year int) (time.Month, int, bool) {
+		dt := EasterSunday(year).AddDate(0, 0, daysFromEaster)
+
+		return dt.Month(), dt.Day(), true
+	}}
+}
+
+// Since returns a copy of the rule that only applies from the given year on, for holidays created by law at some point
+func (r HolidayRule) Since(firstYear int) HolidayRule {
+	date := r.Date
+
+	r.Date = func(year int) (time.Month, int, bool) {
+		if year < firstYear {
+			return 0, 0, false
+		}
+
+		return date(year)
+	}
+
+	return r
+}
+
+// EasterSunday returns the western easter sunday of the given year, at midnight UTC
+// It uses the anonymous gregorian algorithm, also known as Meeus/Jones/Butcher
+func EasterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// BusinessCalendar tells business days apart from weekends and holidays
+// Dates are compared by year, month and day in their own location, so the time of day doesn't matter.
+// A BusinessCalendar is safe for concurrent use
+type BusinessCalendar struct {
+	weekend [7]bool
+	rules   []HolidayRule
+	dates   map[civilDay]string
+
+	mu    sync.Mutex
+	years map[int]map[civilDay]string
+}
+
+type civilDay struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDayOf(t time.Time) civilDay {
+	y, m, d := t.Date()
+
+	return civilDay{y, m, d}
+}
+
+// NewBusinessCalendar returns a calendar without holidays, with the given weekend days
+// When no weekend day is given, saturday and sunday are used
+func NewBusinessCalendar(weekend ...time.Weekday) *BusinessCalendar {
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday, time.Sunday}
+	}
+
+	c := &BusinessCalendar{dates: map[civilDay]string{}, years: map[int]map[civilDay]string{}}
+
+	for _, wd := range weekend {
+		c.weekend[wd] = true
+	}
+
+	return c
+}
+
+// AddHoliday adds holiday rules to the calendar, returning the calendar itself for chaining
+func (c *BusinessCalendar) AddHoliday(rules ...HolidayRule) *BusinessCalendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = append(c.rules, rules...)
+	c.years = map[int]map[civilDay]string{}
+
+	return c
+}
+
+// AddHolidayDate adds a one-time holiday, like a day off decreed for an event, returning the calendar itself for chaining
+func (c *BusinessCalendar) AddHolidayDate(name string, date time.Time) *BusinessCalendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.dates[civilDayOf(date)] = name
+	c.years = map[int]map[civilDay]string{}
+
+	return c
+}
+
+// Extend returns a new calendar with the same weekend and holidays, plus the given rules
+// It's the way to build regional calendars over a national one, without changing it:
+// BusinessCalendarBrazil().Extend(HolidayFixed("Revolução Constitucionalista", time.July, 9))
+func (c *BusinessCalendar) Extend(rules ...HolidayRule) *BusinessCalendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	x := &BusinessCalendar{weekend: c.weekend, dates: make(map[civilDay]string, len(c.dates)), years: map[int]map[civilDay]string{}}
+
+	x.rules = append(append(x.rules, c.rules...), rules...)
+
+	for d, name := range c.dates {
+		x.dates[d] = name
+	}
+
+	return x
+}
+
+// holidaysOf returns the holidays of a year indexed by day, computing them only once
+func (c *BusinessCalendar) holidaysOf(year int) map[civilDay]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if m, ok := c.years[year]; ok {
+		return m
+	}
+
+	m := map[civilDay]string{}
+
+	for _, r := range c.rules {
+		if month, day, ok := r.Date(year); ok {
+			d := civilDay{year, month, day}
+
+			// When two holidays fall on the same day, the first rule names it
+			if _, exists := m[d]; !exists {
+				m[d] = r.Name
+			}
+		}
+	}
+
+	for d, name := range c.dates {
+		if d.year == year {
+			if _, exists := m[d]; !exists {
+				m[d] = name
+			}
+		}
+	}
+
+	c.years[year] = m
+
+	return m
+}
+
+// Holidays returns the holidays of the given year, sorted by date. Dates are at midnight UTC
+func (c *BusinessCalendar) Holidays(year int) []Holiday {
+	m := c.holidaysOf(year)
+
+	a := make([]Holiday, 0, len(m))
+
+	for d, name := range m {
+		a = append(a, Holiday{Name: name, Date: time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)})
+	}
+
+	sort.Slice(a, func(i, j int) bool {
+		return a[i].Date.Before(a[j].Date)
+	})
+
+	return a
+}
+
+// HolidayName returns the name of the holiday at the given date, and false when it isn't a holiday
+func (c *BusinessCalendar) HolidayName(t time.Time) (string, bool) {
+	d := civilDayOf(t)
+
+	name, ok := c.holidaysOf(d.year)[d]
+
+	return name, ok
+}
+
+// IsHoliday returns true when the given date is a holiday, even if it falls on a weekend
+func (c *BusinessCalendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+
+	return ok
+}
+
+// IsWeekend returns true when the given date falls on a weekend day of the calendar
+func (c *BusinessCalendar) IsWeekend(t time.Time) bool {
+	return c.weekend[t.Weekday()]
+}
+
+// IsBusinessDay returns true when the given date is neither a weekend day nor a holiday
+func (c *BusinessCalendar) IsBusinessDay(t time.Time) bool {
+	return !c.IsWeekend(t) && !c.IsHoliday(t)
+}
+
+// step walks day by day in the given direction until a business day is found
+// A calendar with no business days in ten years, like one where every weekday is weekend, makes it give up and return the zero time
+func (c *BusinessCalendar) step(t time.Time, direction int) time.Time {
+	for i := 0; i < businessCalendarMaxStep; i++ {
+		t = t.AddDate(0, 0, direction)
+
+		if c.IsBusinessDay(t) {
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// NextBusinessDay returns the first business day after the given date, keeping its time of day
+func (c *BusinessCalendar) NextBusinessDay(t time.Time) time.Time {
+	return c.step(t, 1)
+}
+
+// PreviousBusinessDay returns the last business day before the given date, keeping its time of day
+func (c *BusinessCalendar) PreviousBusinessDay(t time.Time) time.Time {
+	return c.step(t, -1)
+}
+
+// AddBusinessDays moves the given date forward by n business days, or backwards when n is negative
+// Starting on a weekend or holiday, the first step lands on the next (or previous) business day.
+// Example: from friday, AddBusinessDays(friday, 1) returns the next monday, if it isn't a holiday
+func (c *BusinessCalendar) AddBusinessDays(t time.Time, n int) time.Time {
+	direction := 1
+
+	if n < 0 {
+		direction, n = -1, -n
+	}
+
+	for ; n > 0; n-- {
+		t = c.step(t, direction)
+
+		if t.IsZero() {
+			break
+		}
+	}
+
+	return t
+}
+
+// BusinessDaysBetween counts the business days after "from" up to, and including, "to"
+// The result is negative when "to" is before "from", so AddBusinessDays(from, BusinessDaysBetween(from, to)) returns "to"
+// whenever "to" is a business day
+func (c *BusinessCalendar) BusinessDaysBetween(from, to time.Time) int {
+	start, end := civilDayOf(from), civilDayOf(to.In(from.Location()))
+
+	// Noon avoids surprises with daylight saving time while walking day by day
+	first := time.Date(start.year, start.month, start.day, 12, 0, 0, 0, time.UTC)
+	last := time.Date(end.year, end.month, end.day, 12, 0, 0, 0, time.UTC)
+
+	if !last.Before(first) {
+		return c.countBusinessDays(first.AddDate(0, 0, 1), last.AddDate(0, 0, 1))
+	}
+
+	return -c.countBusinessDays(last, first)
+}
+
+// countBusinessDays counts the business days from "from" up to the day before "to"
+func (c *BusinessCalendar) countBusinessDays(from, to time.Time) int {
+	count := 0
+
+	for dt := from; dt.Before(to); dt = dt.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(dt) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,137 @@
+package handy
+
+import (
+	"testing"
+	"time"
+)
+
+func dateUTC(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestEasterSunday(t *testing.T) {
+	tcs := []struct {
+		year           int
+		expectedOutput time.Time
+	}{
+		{2000, dateUTC(2000, 4, 23)},
+		{2019, dateUTC(2019, 4, 21)},
+		{2024, dateUTC(2024, 3, 31)},
+		{2025, dateUTC(2025, 4, 20)},
+		{2026, dateUTC(2026, 4, 5)},
+		{2038, dateUTC(2038, 4, 25)},
+	}
+
+	for _, tc := range tcs {
+		if tr := EasterSunday(tc.year); !tr.Equal(tc.expectedOutput) {
+			t.Errorf("Test has failed!\n\tYear: %d, \n\tExpected: %s, \n\tGot: %s", tc.year, tc.expectedOutput, tr)
+		}
+	}
+}
+
+func TestBusinessCalendarBrazilHolidays(t *testing.T) {
+	c := BusinessCalendarBrazil()
+
+	tcs := []struct {
+		summary        string
+		date           time.Time
+		expectedOutput string
+	}{
+		{"carnaval monday", dateUTC(2026, 2, 16), "Carnaval"},
+		{"carnaval tuesday", dateUTC(2026, 2, 17), "Carnaval"},
+		{"good friday", dateUTC(2026, 4, 3), "Sexta-feira Santa"},
+		{"corpus christi", dateUTC(2026, 6, 4), "Corpus Christi"},
+		{"tiradentes", dateUTC(2026, 4, 21), "Tiradentes"},
+		{"consciência negra", dateUTC(2026, 11, 20), "Dia Nacional de Zumbi e da Consciência Negra"},
+		{"consciência negra before the law", dateUTC(2023, 11, 20), ""},
+		{"regular day", dateUTC(2026, 10, 19), ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr, _ := c.HolidayName(tc.date)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tDate: %s, \n\tExpected: %s, \n\tGot: %s", tc.date, tc.expectedOutput, tr)
+			}
+		})
+	}
+
+	if n := len(c.Holidays(2026)); n != 13 {
+		t.Errorf("Test has failed!\n\tExpected 13 holidays in 2026, got %d", n)
+	}
+}
+
+func TestBusinessCalendarBusinessDays(t *testing.T) {
+	c := BusinessCalendarBrazil()
+
+	// 2026-02-13 is the friday before carnaval
+	friday := time.Date(2026, 2, 13, 15, 30, 0, 0, time.UTC)
+
+	if c.IsBusinessDay(dateUTC(2026, 2, 14)) || c.IsBusinessDay(dateUTC(2026, 2, 16)) || !c.IsBusinessDay(friday) {
+		t.Errorf("Test has failed! IsBusinessDay() around carnaval")
+	}
+
+	if tr := c.NextBusinessDay(friday); !tr.Equal(time.Date(2026, 2, 18, 15, 30, 0, 0, time.UTC)) {
+		t.Errorf("Test has failed!\n\tExpected next business day on ash wednesday, got %s", tr)
+	}
+
+	if tr := c.PreviousBusinessDay(dateUTC(2026, 2, 18)); !tr.Equal(dateUTC(2026, 2, 13)) {
+		t.Errorf("Test has failed!\n\tExpected previous business day on friday, got %s", tr)
+	}
+
+	tcs := []struct {
+		summary        string
+		from           time.Time
+		days           int
+		expectedOutput time.Time
+	}{
+		{"zero days", friday, 0, friday},
+		{"over carnaval", dateUTC(2026, 2, 13), 3, dateUTC(2026, 2, 20)},
+		{"backwards over carnaval", dateUTC(2026, 2, 20), -3, dateUTC(2026, 2, 13)},
+		{"starting on a weekend", dateUTC(2026, 10, 17), 1, dateUTC(2026, 10, 19)},
+		{"over christmas", dateUTC(2026, 12, 24), 3, dateUTC(2026, 12, 30)},
+		{"over new year", dateUTC(2026, 12, 31), 1, dateUTC(2027, 1, 4)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := c.AddBusinessDays(tc.from, tc.days)
+
+			if !tr.Equal(tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tFrom: %s plus %d, \n\tExpected: %s, \n\tGot: %s", tc.from, tc.days, tc.expectedOutput, tr)
+			}
+
+			if c.IsBusinessDay(tc.expectedOutput) && tc.days != 0 {
+				if n := c.BusinessDaysBetween(tc.from, tr); n != tc.days {
+					t.Errorf("Test has failed!\n\tBusinessDaysBetween(%s, %s) expected %d, got %d", tc.from, tr, tc.days, n)
+				}
+			}
+		})
+	}
+
+	if n := c.BusinessDaysBetween(dateUTC(2026, 10, 1), dateUTC(2026, 10, 31)); n != 20 {
+		t.Errorf("Test has failed!\n\tExpected 20 business days after 2026-10-01 in october, got %d", n)
+	}
+}
+
+func TestBusinessCalendarExtend(t *testing.T) {
+	national := BusinessCalendarBrazil()
+	sp := national.Extend(HolidayFixed("Revolução Constitucionalista", time.July, 9))
+
+	if national.IsHoliday(dateUTC(2026, 7, 9)) || !sp.IsHoliday(dateUTC(2026, 7, 9)) || !sp.IsHoliday(dateUTC(2026, 12, 25)) {
+		t.Errorf("Test has failed! Extend() should add holidays only to the new calendar")
+	}
+
+	sp.AddHolidayDate("Ponto facultativo", dateUTC(2026, 7, 10))
+
+	if !sp.IsHoliday(dateUTC(2026, 7, 10)) || sp.IsHoliday(dateUTC(2027, 7, 10)) {
+		t.Errorf("Test has failed! AddHolidayDate() should add a one-time holiday")
+	}
+
+	noBusinessDays := NewBusinessCalendar(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+
+	if tr := noBusinessDays.AddBusinessDays(dateUTC(2026, 1, 1), 1); !tr.IsZero() {
+		t.Errorf("Test has failed!\n\tExpected zero time, got %s", tr)
+	}
+}
@@ -0,0 +1,113 @@
+package handy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// IdentifierWords splits an identifier or a phrase into words, recognizing acronyms and any kind of separator
+// Digits stick to the word they follow. Accents are folded to ASCII before splitting.
+// Example: IdentifierWords("HTTPRequestAsString") returns []string{"HTTP", "Request", "As", "String"}
+// Example: IdentifierWords("user_id-v2 Ação") returns []string{"user", "id", "v2", "Acao"}
+func IdentifierWords(s string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+	}
+
+	rs := []rune(ASCIIFold(s))
+
+	for i, r := range rs {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+
+			// "requestAs": a lower case letter or digit followed by an upper case one starts a new word
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				flush()
+			} else if unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+				// "HTTPRequest": the last upper case letter of an acronym belongs to the next word
+				flush()
+			}
+		}
+
+		word = append(word, r)
+	}
+
+	flush()
+
+	return words
+}
+
+func joinWords(words []string, separator string, transform func(i int, w string) string) string {
+	for i, w := range words {
+		words[i] = transform(i, w)
+	}
+
+	return strings.Join(words, separator)
+}
+
+func capitalize(w string) string {
+	rs := []rune(strings.ToLower(w))
+
+	if len(rs) > 0 {
+		rs[0] = unicode.ToUpper(rs[0])
+	}
+
+	return string(rs)
+}
+
+// SnakeCase converts the given identifier or phrase to snake_case
+// Example: SnakeCase("HTTPRequestAsString") returns "http_request_as_string"
+func SnakeCase(s string) string {
+	return joinWords(IdentifierWords(s), "_", func(_ int, w string) string {
+		return strings.ToLower(w)
+	})
+}
+
+// KebabCase converts the given identifier or phrase to kebab-case
+// Example: KebabCase("CheckCPF") returns "check-cpf"
+func KebabCase(s string) string {
+	return joinWords(IdentifierWords(s), "-", func(_ int, w string) string {
+		return strings.ToLower(w)
+	})
+}
+
+// ConstantCase converts the given identifier or phrase to CONSTANT_CASE
+// Example: ConstantCase("transformFlagTrim") returns "TRANSFORM_FLAG_TRIM"
+func ConstantCase(s string) string {
+	return joinWords(IdentifierWords(s), "_", func(_ int, w string) string {
+		return strings.ToUpper(w)
+	})
+}
+
+// PascalCase converts the given identifier or phrase to PascalCase. Acronyms are capitalized as regular words.
+// Example: PascalCase("http_request_as_string") returns "HttpRequestAsString"
+func PascalCase(s string) string {
+	return joinWords(IdentifierWords(s), "", func(_ int, w string) string {
+		return capitalize(w)
+	})
+}
+
+// CamelCase converts the given identifier or phrase to camelCase. Acronyms are capitalized as regular words.
+// Example: CamelCase("HTTPRequestAsString") returns "httpRequestAsString"
+func CamelCase(s string) string {
+	return joinWords(IdentifierWords(s), "", func(i int, w string) string {
+		if i == 0 {
+			return strings.ToLower(w)
+		}
+
+		return capitalize(w)
+	})
+}
@@ -0,0 +1,48 @@
+package handy
+
+import "testing"
+
+func TestIdentifierCases(t *testing.T) {
+	tcs := []struct {
+		input    string
+		snake    string
+		kebab    string
+		constant string
+		pascal   string
+		camel    string
+	}{
+		{"", "", "", "", "", ""},
+		{"HTTPRequestAsString", "http_request_as_string", "http-request-as-string", "HTTP_REQUEST_AS_STRING", "HttpRequestAsString", "httpRequestAsString"},
+		{"CheckCPF", "check_cpf", "check-cpf", "CHECK_CPF", "CheckCpf", "checkCpf"},
+		{"StringAsFloat", "string_as_float", "string-as-float", "STRING_AS_FLOAT", "StringAsFloat", "stringAsFloat"},
+		{"transformFlagTrim", "transform_flag_trim", "transform-flag-trim", "TRANSFORM_FLAG_TRIM", "TransformFlagTrim", "transformFlagTrim"},
+		{"EnvInt64", "env_int64", "env-int64", "ENV_INT64", "EnvInt64", "envInt64"},
+		{"http_request_as_string", "http_request_as_string", "http-request-as-string", "HTTP_REQUEST_AS_STRING", "HttpRequestAsString", "httpRequestAsString"},
+		{"  nome do usuário ", "nome_do_usuario", "nome-do-usuario", "NOME_DO_USUARIO", "NomeDoUsuario", "nomeDoUsuario"},
+		{"CONSTANT_CASE-value", "constant_case_value", "constant-case-value", "CONSTANT_CASE_VALUE", "ConstantCaseValue", "constantCaseValue"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			if r := SnakeCase(tc.input); r != tc.snake {
+				t.Errorf("Test has failed! SnakeCase expected: %s, got: %s", tc.snake, r)
+			}
+
+			if r := KebabCase(tc.input); r != tc.kebab {
+				t.Errorf("Test has failed! KebabCase expected: %s, got: %s", tc.kebab, r)
+			}
+
+			if r := ConstantCase(tc.input); r != tc.constant {
+				t.Errorf("Test has failed! ConstantCase expected: %s, got: %s", tc.constant, r)
+			}
+
+			if r := PascalCase(tc.input); r != tc.pascal {
+				t.Errorf("Test has failed! PascalCase expected: %s, got: %s", tc.pascal, r)
+			}
+
+			if r := CamelCase(tc.input); r != tc.camel {
+				t.Errorf("Test has failed! CamelCase expected: %s, got: %s", tc.camel, r)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package handy
+
+// catalogBuiltinMessages holds the messages DefaultCatalog ships with
+// Brazilian portuguese messages are registered as "pt", so both "pt-BR" and "pt-PT" find them
+var catalogBuiltinMessages = map[string]map[string]string{
+	"en": {
+		"unknown_error": "Unknow error",
+
+		"personname.ok":            "Name is well formed",
+		"personname.polluted":      "Name accepts only letters and spaces",
+		"personname.too_few_words": "Name should be composed by at least two words",
+		"personname.too_short":     "Name should be composed by at least two words, been one with 2 and the other with at least 3 letters",
+		"personname.too_simple":    "Name too short or empty",
+
+		"password.ok":         "Password Validated",
+		"password.divergent":  "Password and confirmation doesn't match",
+		"password.too_short":  "Password should contains at least 6 characters, mixing letters and numbers",
+		"password.too_simple": "Password should contains at least 6 characters, mixing letters and numbers",
+
+		"date.invalid":      "date or format invalid",
+		"date.out_of_range": "date out of range",
+		"date.empty":        "date undefined",
+
+		"checkstr.ok":                           "Text is well formed",
+		"checkstr.empty_denied":                 "Text can't be empty",
+		"checkstr.too_short":                    "Text too short",
+		"checkstr.too_long":                     "Text too long",
+		"checkstr.space_denied":                 "Text can't contain spaces",
+		"checkstr.numbers_denied":               "Text can't contain numbers",
+		"checkstr.letters_denied":               "Text can't contain letters",
+		"checkstr.symbols_denied":               "Text can't contain symbols",
+		"checkstr.more_than_one_word_denied":    "Text can't contain more than one word",
+		"checkstr.uppercase_denied":             "Text can't contain uppercase letters",
+		"checkstr.lowercase_denied":             "Text can't contain lowercase letters",
+		"checkstr.unicode_denied":               "Text can't contain special or accented characters",
+		"checkstr.numbers_not_found":            "Text should contain at least one number",
+		"checkstr.letters_not_found":            "Text should contain at least one letter",
+		"checkstr.symbols_not_found":            "Text should contain at least one symbol",
+		"checkstr.more_than_one_word_not_found": "Text should contain at least two words",
+		"checkstr.uppercase_not_found":          "Text should contain at least one uppercase letter",
+		"checkstr.lowercase_not_found":          "Text should contain at least one lowercase letter",
+
+		"validation.invalid":  "invalid value",
+		"validation.type":     "rule {rule} doesn't apply to this field type",
+		"validation.required": "field required",
+		"validation.email":    "invalid email address",
+		"validation.cpf":      "invalid CPF",
+		"validation.cnpj":     "invalid CNPJ",
+		"validation.phone":    "invalid phone number",
+		"validation.min":      "should be greater than or equal to {param}",
+		"validation.max":      "should be less than or equal to {param}",
+		"validation.oneof":    "should be one of: {options}",
+
+		"humanize.and":         "and",
+		"humanize.ago":         "{duration} ago",
+		"humanize.in":          "in {duration}",
+		"humanize.now":         "just now",
+		"humanize.yesterday":   "yesterday at {time}",
+		"humanize.tomorrow":    "tomorrow at {time}",
+		"humanize.time_format": "hh24:nn",
+	},
+	"pt": {
+		"unknown_error": "Erro desconhecido",
+
+		"personname.ok":            "Nome Válido",
+		"personname.polluted":      "O campo nome permite apenas letras e espaços",
+		"personname.too_few_words": "O nome deve ser composto de ao menos duas palavras",
+		"personname.too_short":     "O nome deve ser composto de ao menos duas palavras, sendo uma com três e outra com ao menos duas letras",
+		"personname.too_simple":    "Nome muito curto ou vazio",
+
+		"password.ok":         "Senha Válida",
+		"password.divergent":  "Senha diferente da confirmação",
+		"password.too_short":  "Senha deve conter ao menos 6 caracteres, entre números e letras",
+		"password.too_simple": "Senha deve conter ao menos 6 caracteres, entre números e letras",
+
+		"date.invalid":      "data ou formato inválido",
+		"date.out_of_range": "data fora do intervalo permitido",
+		"date.empty":        "data não definida",
+
+		"checkstr.ok":                           "Texto válido",
+		"checkstr.empty_denied":                 "O texto não pode ser vazio",
+		"checkstr.too_short":                    "Texto muito curto",
+		"checkstr.too_long":                     "Texto muito longo",
+		"checkstr.space_denied":                 "O texto não pode conter espaços",
+		"checkstr.numbers_denied":               "O texto não pode conter números",
+		"checkstr.letters_denied":               "O texto não pode conter letras",
+		"checkstr.symbols_denied":               "O texto não pode conter símbolos",
+		"checkstr.more_than_one_word_denied":    "O texto não pode conter mais de uma palavra",
+		"checkstr.uppercase_denied":             "O texto não pode conter letras maiúsculas",
+		"checkstr.lowercase_denied":             "O texto não pode conter letras minúsculas",
+		"checkstr.unicode_denied":               "O texto não pode conter caracteres especiais ou acentuados",
+		"checkstr.numbers_not_found":            "O texto deve conter ao menos um número",
+		"checkstr.letters_not_found":            "O texto deve conter ao menos uma letra",
+		"checkstr.symbols_not_found":            "O texto deve conter ao menos um símbolo",
+		"checkstr.more_than_one_word_not_found": "O texto deve conter ao menos duas palavras",
+		"checkstr.uppercase_not_found":          "O texto deve conter ao menos uma letra maiúscula",
+		"checkstr.lowercase_not_found":          "O texto deve conter ao menos uma letra minúscula",
+
+		"validation.invalid":  "valor inválido",
+		"validation.type":     "a regra {rule} não se aplica a este tipo de campo",
+		"validation.required": "campo obrigatório",
+		"validation.email":    "e-mail inválido",
+		"validation.cpf":      "CPF inválido",
+		"validation.cnpj":     "CNPJ inválido",
+		"validation.phone":    "telefone inválido",
+		"validation.min":      "deve ser maior ou igual a {param}",
+		"validation.max":      "deve ser menor ou igual a {param}",
+		"validation.oneof":    "deve ser um dos valores: {options}",
+
+		"humanize.and":         "e",
+		"humanize.ago":         "há {duration}",
+		"humanize.in":          "em {duration}",
+		"humanize.now":         "agora",
+		"humanize.yesterday":   "ontem às {time}",
+		"humanize.tomorrow":    "amanhã às {time}",
+		"humanize.time_format": "hh24:nn",
+	},
+}
+
+// catalogBuiltinPlurals holds the plural messages DefaultCatalog ships with
+var catalogBuiltinPlurals = map[string]map[string]map[string]string{
+	"en": {
+		"checkstr.too_short_min": {PluralOne: "{message} (minimum of {count} character)", PluralOther: "{message} (minimum of {count} characters)"},
+		"checkstr.too_long_max":  {PluralOne: "{message} (maximum of {count} character)", PluralOther: "{message} (maximum of {count} characters)"},
+		"validation.minlen":      {PluralOne: "should contain at least {count} character", PluralOther: "should contain at least {count} characters"},
+		"validation.maxlen":      {PluralOne: "should contain at most {count} character", PluralOther: "should contain at most {count} characters"},
+		"validation.agemin":      {PluralOne: "minimum age is {count} year", PluralOther: "minimum age is {count} years"},
+		"validation.agemax":      {PluralOne: "maximum age is {count} year", PluralOther: "maximum age is {count} years"},
+
+		"humanize.year":   {PluralOne: "{count} year", PluralOther: "{count} years"},
+		"humanize.month":  {PluralOne: "{count} month", PluralOther: "{count} months"},
+		"humanize.week":   {PluralOne: "{count} week", PluralOther: "{count} weeks"},
+		"humanize.day":    {PluralOne: "{count} day", PluralOther: "{count} days"},
+		"humanize.hour":   {PluralOne: "{count} hour", PluralOther: "{count} hours"},
+		"humanize.minute": {PluralOne: "{count} minute", PluralOther: "{count} minutes"},
+		"humanize.second": {PluralOne: "{count} second", PluralOther: "{count} seconds"},
+	},
+	"pt": {
+		"checkstr.too_short_min": {PluralOne: "{message} (mínimo de {count} caractere)", PluralOther: "{message} (mínimo de {count} caracteres)"},
+		"checkstr.too_long_max":  {PluralOne: "{message} (máximo de {count} caractere)", PluralOther: "{message} (máximo de {count} caracteres)"},
+		"validation.minlen":      {PluralOne: "deve conter ao menos {count} caractere", PluralOther: "deve conter ao menos {count} caracteres"},
+		"validation.maxlen":      {PluralOne: "deve conter no máximo {count} caractere", PluralOther: "deve conter no máximo {count} caracteres"},
+		"validation.agemin":      {PluralOne: "idade mínima de {count} ano", PluralOther: "idade mínima de {count} anos"},
+		"validation.agemax":      {PluralOne: "idade máxima de {count} ano", PluralOther: "idade máxima de {count} anos"},
+
+		"humanize.year":   {PluralZero: "{count} anos", PluralOne: "{count} ano", PluralOther: "{count} anos"},
+		"humanize.month":  {PluralZero: "{count} meses", PluralOne: "{count} mês", PluralOther: "{count} meses"},
+		"humanize.week":   {PluralZero: "{count} semanas", PluralOne: "{count} semana", PluralOther: "{count} semanas"},
+		"humanize.day":    {PluralZero: "{count} dias", PluralOne: "{count} dia", PluralOther: "{count} dias"},
+		"humanize.hour":   {PluralZero: "{count} horas", PluralOne: "{count} hora", PluralOther: "{count} horas"},
+		"humanize.minute": {PluralZero: "{count} minutos", PluralOne: "{count} minuto", PluralOther: "{count} minutos"},
+		"humanize.second": {PluralZero: "{count} segundos", PluralOne: "{count} segundo", PluralOther: "{count} segundos"},
+	},
+}
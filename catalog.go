@@ -0,0 +1,381 @@
+package handy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// CatalogFallbackLanguage is the last language tried when a message isn't found in the requested language
+	CatalogFallbackLanguage = "en"
+
+	// PluralZero and the following constants are the CLDR plural categories used as keys of plural messages
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// CatalogParams holds the values to replace "{name}" placeholders within messages
+type CatalogParams map[string]interface{}
+
+// PluralRule returns the CLDR plural category, like PluralOne or PluralOther, for the given quantity
+type PluralRule func(n int) string
+
+// catalogMessage holds a simple text, or the plural forms of a message, indexed by CLDR category
+type catalogMessage struct {
+	text  string
+	forms map[string]string
+}
+
+// Catalog is a set of messages indexed by BCP-47 language tag and key
+// When a message is missing, the lookup falls back through the tag parents (pt-BR → pt) and finally to CatalogFallbackLanguage.
+// A Catalog is safe for concurrent use
+type Catalog struct {
+	mu          sync.RWMutex
+	messages    map[string]map[string]catalogMessage
+	pluralRules map[string]PluralRule
+	aliases     map[string]string
+}
+
+// DefaultCatalog is the catalog used by handy's own translators, like CheckPersonNameResult() and DateStrCheckErrMessage()
+// It ships with english and brazilian portuguese messages. Register more languages with Set() or LoadJSON().
+var DefaultCatalog = NewCatalog()
+
+func init() {
+	for lang, messages := range catalogBuiltinMessages {
+		for key, text := range messages {
+			DefaultCatalog.Set(lang, key, text)
+		}
+	}
+
+	for lang, messages := range catalogBuiltinPlurals {
+		for key, forms := range messages {
+			DefaultCatalog.SetPlural(lang, key, forms)
+		}
+	}
+
+	// "bra" was the idiom code accepted by handy before the catalog
+	DefaultCatalog.Alias("bra", "pt-BR")
+}
+
+// NewCatalog returns an empty catalog, with plural rules for some common languages already registered
+func NewCatalog() *Catalog {
+	one := func(n int) string {
+		if n == 1 {
+			return PluralOne
+		}
+
+		return PluralOther
+	}
+
+	zeroOrOne := func(n int) string {
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+
+		return PluralOther
+	}
+
+	return &Catalog{
+		messages: map[string]map[string]catalogMessage{},
+		pluralRules: map[string]PluralRule{
+			"en":    one,
+			"de":    one,
+			"es":    one,
+			"it":    one,
+			"pt-PT": one,
+			"pt":    zeroOrOne,
+			"fr":    zeroOrOne,
+		},
+		aliases: map[string]string{},
+	}
+}
+
+// CatalogCanonicalTag normalizes a BCP-47 language tag, like "pt_br" to "pt-BR"
+// Language goes in lowercase, four letters scripts in title case and regions in uppercase
+func CatalogCanonicalTag(tag string) string {
+	parts := strings.Split(strings.Replace(strings.TrimSpace(tag), "_", "-", -1), "-")
+
+	for i, p := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(p)
+		case len(p) == 4:
+			parts[i] = strings.Title(strings.ToLower(p))
+		case len(p) == 2 || len(p) == 3 && HasOnlyDigits(p):
+			parts[i] = strings.ToUpper(p)
+		default:
+			parts[i] = strings.ToLower(p)
+		}
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// Alias makes the catalog resolve a custom language code to a BCP-47 tag. I.E: Alias("bra", "pt-BR")
+func (c *Catalog) Alias(alias, tag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.aliases[strings.ToLower(alias)] = CatalogCanonicalTag(tag)
+}
+
+// FallbackChain returns the languages tried, in order, when looking up a message for the given tag
+// Example: FallbackChain("pt-BR") returns []string{"pt-BR", "pt", "en"}
+func (c *Catalog) FallbackChain(tag string) []string {
+	c.mu.RLock()
+	alias, ok := c.aliases[strings.ToLower(strings.TrimSpace(tag))]
+	c.mu.RUnlock()
+
+	if ok {
+		tag = alias
+	}
+
+	chain := catalogTagParents(CatalogCanonicalTag(tag))
+
+	if len(chain) == 0 || chain[len(chain)-1] != CatalogFallbackLanguage {
+		chain = append(chain, CatalogFallbackLanguage)
+	}
+
+	return chain
+}
+
+// Set registers a simple message for the given language and key
+func (c *Catalog) Set(tag, key, text string) {
+	c.set(tag, key, catalogMessage{text: text})
+}
+
+// SetPlural registers a message with plural forms, indexed by CLDR category: PluralZero, PluralOne, ..., PluralOther
+// PluralOther is mandatory, as it's the fallback for missing forms. PluralZero, when present, is always used for zero.
+func (c *Catalog) SetPlural(tag, key string, forms map[string]string) {
+	m := catalogMessage{forms: make(map[string]string, len(forms))}
+
+	for category, text := range forms {
+		m.forms[category] = text
+	}
+
+	m.text = forms[PluralOther]
+
+	c.set(tag, key, m)
+}
+
+func (c *Catalog) set(tag, key string, m catalogMessage) {
+	tag = CatalogCanonicalTag(tag)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[tag] == nil {
+		c.messages[tag] = map[string]catalogMessage{}
+	}
+
+	c.messages[tag][key] = m
+}
+
+// PluralRuleRegister sets the plural rule for a language. Languages without a rule use the english one.
+func (c *Catalog) PluralRuleRegister(tag string, rule PluralRule) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pluralRules[CatalogCanonicalTag(tag)] = rule
+}
+
+// LoadJSON reads messages from JSON, merging them into the catalog
+// The expected format is an object indexed by language tag, where each message is a string, or an object of plural forms:
+// {"es": {"date.empty": "fecha no definida", "items": {"one": "{count} elemento", "other": "{count} elementos"}}}
+// To load embedded files, just wrap their content with bytes.NewReader()
+func (c *Catalog) LoadJSON(r io.Reader) error {
+	var raw map[string]map[string]json.RawMessage
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return fmt.Errorf("handy: invalid catalog: %v", err)
+	}
+
+	for tag, messages := range raw {
+		for key, rm := range messages {
+			var text string
+
+			if err := json.Unmarshal(rm, &text); err == nil {
+				c.Set(tag, key, text)
+				continue
+			}
+
+			var forms map[string]string
+
+			if err := json.Unmarshal(rm, &forms); err != nil {
+				return fmt.Errorf("handy: invalid catalog message %s/%s: expected string or plural forms", tag, key)
+			}
+
+			if _, ok := forms[PluralOther]; !ok {
+				return fmt.Errorf(`handy: invalid catalog message %s/%s: plural form "other" is missing`, tag, key)
+			}
+
+			c.SetPlural(tag, key, forms)
+		}
+	}
+
+	return nil
+}
+
+// LoadJSONFile reads messages from a JSON file, as LoadJSON() does
+func (c *Catalog) LoadJSONFile(fileName string) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return c.LoadJSON(f)
+}
+
+// Languages returns the tags of all languages that have at least one message, sorted
+func (c *Catalog) Languages() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	a := make([]string, 0, len(c.messages))
+
+	for tag := range c.messages {
+		a = append(a, tag)
+	}
+
+	sort.Strings(a)
+
+	return a
+}
+
+// lookup finds the message following the fallback chain, returning also the language where it was found
+func (c *Catalog) lookup(tag, key string) (catalogMessage, string, bool) {
+	chain := c.FallbackChain(tag)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, lang := range chain {
+		if m, ok := c.messages[lang][key]; ok {
+			return m, lang, true
+		}
+	}
+
+	return catalogMessage{}, "", false
+}
+
+// Has returns true if the key can be resolved for the given language, considering the fallback chain
+func (c *Catalog) Has(tag, key string) bool {
+	_, _, ok := c.lookup(tag, key)
+
+	return ok
+}
+
+// Translate returns the message for the given language and key, with "{name}" placeholders replaced by params
+// When the key isn't found at all, the key itself is returned
+func (c *Catalog) Translate(tag, key string, params CatalogParams) string {
+	m, _, ok := c.lookup(tag, key)
+
+	if !ok {
+		return key
+	}
+
+	return catalogInterpolate(m.text, params)
+}
+
+// TranslatePlural returns the plural form of the message that suits the given quantity
+// The quantity is available to the message as the "{count}" placeholder
+// Example: TranslatePlural("pt-BR", "checkstr.too_short_min", 1, CatalogParams{"min": 1})
+func (c *Catalog) TranslatePlural(tag, key string, count int, params CatalogParams) string {
+	m, lang, ok := c.lookup(tag, key)
+
+	if !ok {
+		return key
+	}
+
+	p := CatalogParams{"count": count}
+
+	for k, v := range params {
+		p[k] = v
+	}
+
+	text := m.text
+
+	if m.forms != nil {
+		category := c.pluralCategory(lang, count)
+
+		if f, ok := m.forms[PluralZero]; ok && count == 0 {
+			text = f
+		} else if f, ok := m.forms[category]; ok {
+			text = f
+		}
+	}
+
+	return catalogInterpolate(text, p)
+}
+
+func (c *Catalog) pluralCategory(lang string, n int) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, tag := range catalogTagParents(lang) {
+		if rule, ok := c.pluralRules[tag]; ok {
+			return rule(n)
+		}
+	}
+
+	if n == 1 {
+		return PluralOne
+	}
+
+	return PluralOther
+}
+
+// catalogTagParents returns the tag followed by its parents, like "pt-BR", "pt"
+func catalogTagParents(tag string) []string {
+	var chain []string
+
+	for tag != "" {
+		chain = append(chain, tag)
+
+		i := strings.LastIndex(tag, "-")
+
+		if i < 0 {
+			break
+		}
+
+		tag = tag[:i]
+	}
+
+	return chain
+}
+
+func catalogInterpolate(text string, params CatalogParams) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Translate returns a message from DefaultCatalog. See Catalog.Translate()
+func Translate(tag, key string, params CatalogParams) string {
+	return DefaultCatalog.Translate(tag, key, params)
+}
+
+// TranslatePlural returns a plural message from DefaultCatalog. See Catalog.TranslatePlural()
+func TranslatePlural(tag, key string, count int, params CatalogParams) string {
+	return DefaultCatalog.TranslatePlural(tag, key, count, params)
+}
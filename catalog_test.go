@@ -0,0 +1,112 @@
+package handy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCatalogFallbackChain(t *testing.T) {
+	c := NewCatalog()
+	c.Alias("bra", "pt-BR")
+
+	tcs := []struct {
+		tag   string
+		chain []string
+	}{
+		{"pt-BR", []string{"pt-BR", "pt", "en"}},
+		{"pt_br", []string{"pt-BR", "pt", "en"}},
+		{"bra", []string{"pt-BR", "pt", "en"}},
+		{"zh-hant-tw", []string{"zh-Hant-TW", "zh-Hant", "zh", "en"}},
+		{"en-US", []string{"en-US", "en"}},
+		{"", []string{"en"}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.tag, func(t *testing.T) {
+			if chain := c.FallbackChain(tc.tag); !reflect.DeepEqual(chain, tc.chain) {
+				t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", tc.chain, chain)
+			}
+		})
+	}
+}
+
+func TestCatalogTranslate(t *testing.T) {
+	c := NewCatalog()
+
+	c.Set("en", "greeting", "Hello, {name}!")
+	c.Set("pt", "greeting", "Olá, {name}!")
+	c.Set("pt-PT", "greeting", "Viva, {name}!")
+	c.SetPlural("en", "files", map[string]string{PluralZero: "no files", PluralOne: "{count} file", PluralOther: "{count} files"})
+	c.SetPlural("pt", "files", map[string]string{PluralOne: "{count} arquivo", PluralOther: "{count} arquivos"})
+
+	tcs := []struct {
+		summary string
+		result  string
+		output  string
+	}{
+		{"exact", c.Translate("en", "greeting", CatalogParams{"name": "Gopher"}), "Hello, Gopher!"},
+		{"parent", c.Translate("pt-BR", "greeting", CatalogParams{"name": "Gopher"}), "Olá, Gopher!"},
+		{"region", c.Translate("pt-PT", "greeting", CatalogParams{"name": "Gopher"}), "Viva, Gopher!"},
+		{"fallback", c.Translate("es", "greeting", CatalogParams{"name": "Gopher"}), "Hello, Gopher!"},
+		{"missing key", c.Translate("es", "nothing", nil), "nothing"},
+		{"plural zero", c.TranslatePlural("en", "files", 0, nil), "no files"},
+		{"plural one", c.TranslatePlural("en", "files", 1, nil), "1 file"},
+		{"plural other", c.TranslatePlural("en", "files", 7, nil), "7 files"},
+		{"portuguese zero is singular", c.TranslatePlural("pt-BR", "files", 0, nil), "0 arquivo"},
+		{"portuguese plural", c.TranslatePlural("pt-BR", "files", 2, nil), "2 arquivos"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if tc.result != tc.output {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", tc.output, tc.result)
+			}
+		})
+	}
+}
+
+func TestCatalogLoadJSON(t *testing.T) {
+	c := NewCatalog()
+
+	j := `{"es": {"date.empty": "fecha no definida", "items": {"one": "{count} elemento", "other": "{count} elementos"}}}`
+
+	if err := c.LoadJSON(strings.NewReader(j)); err != nil {
+		t.Fatal(err)
+	}
+
+	if s := c.Translate("es-AR", "date.empty", nil); s != "fecha no definida" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+
+	if s := c.TranslatePlural("es", "items", 3, nil); s != "3 elementos" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+
+	for _, bad := range []string{`[]`, `{"es": {"x": 1}}`, `{"es": {"x": {"one": "a"}}}`} {
+		if err := c.LoadJSON(strings.NewReader(bad)); err == nil {
+			t.Errorf("Test has failed! %s should be refused", bad)
+		}
+	}
+}
+
+func TestDefaultCatalogDrivesTranslators(t *testing.T) {
+	DefaultCatalog.Set("es", "date.empty", "fecha no definida")
+	DefaultCatalog.Set("es", "personname.polluted", "El nombre solo acepta letras y espacios")
+
+	if s := DateStrCheckErrMessage("es", DateStrCheckErrEmpty); s != "fecha no definida" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+
+	if s := CheckPersonNameResult("es-MX", CheckPersonNameResultPolluted); s != "El nombre solo acepta letras y espacios" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+
+	if s := CheckNewPasswordResult("pt-BR", CheckNewPasswordResultDivergent); s != "Senha diferente da confirmação" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+
+	if s := CheckStrResult("pt", CheckStrTooLong); s != "Texto muito longo" {
+		t.Errorf("Test has failed! Got %s", s)
+	}
+}
@@ -0,0 +1,205 @@
+package handy
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Date helpers, like Today() and YearsAge(), ask it instead of calling time.Now() directly,
+// so tests can control the time with a FakeClock
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the real clock, backed by time.Now()
+type SystemClock struct{}
+
+// Now returns time.Now()
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a controllable clock for tests. It's safe for concurrent use
+// It starts frozen, so the time only changes through Set() and Advance(), until Resume() is called.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	running bool
+	since   time.Time
+}
+
+// NewFakeClock returns a frozen FakeClock at the given time
+func NewFakeClock(t time.Time) *FakeClock {
+	return &FakeClock{now: t}
+}
+
+// Now returns the fake current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.current()
+}
+
+func (c *FakeClock) current() time.Time {
+	if c.running {
+		return c.now.Add(time.Since(c.since))
+	}
+
+	return c.now
+}
+
+// Set moves the clock to the given time
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = t
+	c.since = time.Now()
+}
+
+// Advance moves the clock forward by d, or backwards when d is negative
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.current().Add(d)
+	c.since = time.Now()
+}
+
+// Freeze stops the clock at its current time
+func (c *FakeClock) Freeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.current()
+	c.running = false
+}
+
+// Resume makes the clock run at real speed from its current time
+func (c *FakeClock) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.current()
+	c.since = time.Now()
+	c.running = true
+}
+
+var packageClock = struct {
+	sync.RWMutex
+	clock Clock
+}{clock: SystemClock{}}
+
+// ClockSet replaces the clock used by the package-level date helpers, returning a function that restores the previous one
+// Typical use in tests: defer handy.ClockSet(handy.NewFakeClock(birthday))()
+// As the clock is shared by the whole package, tests running in parallel should prefer WithClock()
+func ClockSet(c Clock) (restore func()) {
+	if c == nil {
+		c = SystemClock{}
+	}
+
+	packageClock.Lock()
+	previous := packageClock.clock
+	packageClock.clock = c
+	packageClock.Unlock()
+
+	return func() {
+		packageClock.Lock()
+		packageClock.clock = previous
+		packageClock.Unlock()
+	}
+}
+
+// ClockNow returns the current time according the package clock
+func ClockNow() time.Time {
+	packageClock.RLock()
+	defer packageClock.RUnlock()
+
+	return packageClock.clock.Now()
+}
+
+// ClockHelpers gives the date helpers that depend on the current time, bound to a given clock
+type ClockHelpers struct {
+	clock Clock
+}
+
+// WithClock returns the date helpers bound to the given clock, without touching the package clock
+// Example: handy.WithClock(fake).YearsAge(birthdate)
+func WithClock(c Clock) ClockHelpers {
+	if c == nil {
+		c = SystemClock{}
+	}
+
+	return ClockHelpers{clock: c}
+}
+
+// packageHelpers returns the helpers bound to the package clock
+func packageHelpers() ClockHelpers {
+	packageClock.RLock()
+	defer packageClock.RUnlock()
+
+	return ClockHelpers{clock: packageClock.clock}
+}
+
+// Now returns the current time according the clock
+func (h ClockHelpers) Now() time.Time {
+	return h.clock.Now()
+}
+
+// NowAsString formats the current time as string, considering the format directive
+func (h ClockHelpers) NowAsString(format string) string {
+	return DateTimeAsString(h.clock.Now(), format)
+}
+
+// Today returns today's date at zero hours, in the local time zone, and a yyyy-mm-dd formated string
+func (h ClockHelpers) Today() (time.Time, string) {
+	return h.Todayf("yyyy-mm-dd")
+}
+
+// Todayf returns today's date at zero hours, in the local time zone, and a custom formated string
+func (h ClockHelpers) Todayf(format string) (time.Time, string) {
+	t := h.clock.Now()
+	y := t.Year()
+	m := t.Month()
+	d := t.Day()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local), DateTimeAsString(t, format)
+}
+
+// YMD returns today's date tokenized as year, month and day of month
+func (h ClockHelpers) YMD() (int, int, int) {
+	t := h.clock.Now()
+
+	return t.Year(), int(t.Month()), t.Day()
+}
+
+// YearsAge returns the number of years past since a given date
+func (h ClockHelpers) YearsAge(birthdate time.Time) int {
+	return ElapsedYears(birthdate, h.clock.Now())
+}
+
+// DateStrCheckAge checks a date string considering minimum and maximum age. See the package-level DateStrCheckAge()
+func (h ClockHelpers) DateStrCheckAge(date, format string, yearsAgeMin, yearsAgeMax int, acceptEmpty bool) DateStrCheck {
+	if date == "" {
+		if !acceptEmpty {
+			return DateStrCheckErrEmpty
+		}
+
+		return DateStrCheckOk
+	}
+
+	dt := StringAsDateTime(date, format)
+
+	if dt.IsZero() {
+		return DateStrCheckErrInvalid
+	}
+
+	yearsAge := h.YearsAge(dt)
+
+	if !Between(yearsAge, yearsAgeMin, yearsAgeMax) {
+		return DateStrCheckErrOutOfRange
+	}
+
+	return DateStrCheckOk
+}
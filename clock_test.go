@@ -0,0 +1,86 @@
+package handy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	c := NewFakeClock(start)
+
+	if !c.Now().Equal(start) {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", start, c.Now())
+	}
+
+	c.Advance(36 * time.Hour)
+
+	if expected := start.Add(36 * time.Hour); !c.Now().Equal(expected) {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", expected, c.Now())
+	}
+
+	c.Set(start)
+	c.Resume()
+
+	time.Sleep(2 * time.Millisecond)
+
+	if !c.Now().After(start) {
+		t.Errorf("Test has failed!\n\tExpected a running clock after %s, got %s", start, c.Now())
+	}
+
+	c.Freeze()
+
+	frozen := c.Now()
+
+	time.Sleep(2 * time.Millisecond)
+
+	if !c.Now().Equal(frozen) {
+		t.Errorf("Test has failed!\n\tExpected a frozen clock at %s, got %s", frozen, c.Now())
+	}
+}
+
+func TestClockSet(t *testing.T) {
+	now := time.Date(2019, 6, 1, 0, 0, 0, 0, time.Local)
+
+	restore := ClockSet(NewFakeClock(now))
+
+	if y, m, d := YMD(); y != 2019 || m != 6 || d != 1 {
+		t.Errorf("Test has failed!\n\tExpected: 2019 6 1, \n\tGot: %d %d %d", y, m, d)
+	}
+
+	if s := NowAsString("dd/mm/yyyy"); s != "01/06/2019" {
+		t.Errorf("Test has failed!\n\tExpected: 01/06/2019, \n\tGot: %s", s)
+	}
+
+	restore()
+
+	if ClockNow().Year() == 2019 {
+		t.Errorf("Test has failed! The package clock wasn't restored")
+	}
+}
+
+func TestWithClockAgeGate(t *testing.T) {
+	birthday := time.Date(2001, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		summary        string
+		now            time.Time
+		expectedOutput DateStrCheck
+	}{
+		{"the day before the 18th birthday", time.Date(2019, 10, 17, 23, 59, 59, 0, time.UTC), DateStrCheckErrOutOfRange},
+		{"the 18th birthday", time.Date(2019, 10, 18, 0, 0, 0, 0, time.UTC), DateStrCheckOk},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			h := WithClock(NewFakeClock(tc.now))
+
+			tr := h.DateStrCheckAge(DateTimeAsString(birthday, "dd/mm/yyyy"), "dd/mm/yyyy", 18, 120, false)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tNow: %s, \n\tExpected: %d, \n\tGot: %d", tc.now, tc.expectedOutput, tr)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package handy
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sentinel errors for each CheckStr rule, to be tested with errors.Is() against the result of CheckStrAll()
+var (
+	ErrCheckStrEmptyDenied             = errors.New("handy: empty string denied")
+	ErrCheckStrTooShort                = errors.New("handy: string too short")
+	ErrCheckStrTooLong                 = errors.New("handy: string too long")
+	ErrCheckStrSpaceDenied             = errors.New("handy: spaces denied")
+	ErrCheckStrNumbersDenied           = errors.New("handy: numbers denied")
+	ErrCheckStrLettersDenied           = errors.New("handy: letters denied")
+	ErrCheckStrSymbolsDenied           = errors.New("handy: symbols denied")
+	ErrCheckStrMoreThanOneWordDenied   = errors.New("handy: more than one word denied")
+	ErrCheckStrUpperCaseDenied         = errors.New("handy: uppercase letters denied")
+	ErrCheckStrLowercaseDenied         = errors.New("handy: lowercase letters denied")
+	ErrCheckStrUnicodeDenied           = errors.New("handy: non-ASCII characters denied")
+	ErrCheckStrNumbersNotFound         = errors.New("handy: numbers required")
+	ErrCheckStrLettersNotFound         = errors.New("handy: letters required")
+	ErrCheckStrSymbolsNotFound         = errors.New("handy: symbols required")
+	ErrCheckStrMoreThanOneWordNotFound = errors.New("handy: more than one word required")
+	ErrCheckStrUpperCaseNotFound       = errors.New("handy: uppercase letters required")
+	ErrCheckStrLowercaseNotFound       = errors.New("handy: lowercase letters required")
+)
+
+// CheckStrViolation describes a single broken CheckStr rule
+type CheckStrViolation struct {
+	// Rule is a short name for the broken rule, like "deny-numbers" or "require-symbols"
+	Rule string
+	// Code is the same int8 code CheckStr returns for this rule, like CheckStrNubersDenied
+	Code int8
+	// Positions holds the zero-based rune indexes of the offending characters, for "deny" rules
+	Positions []int
+	// Length is the string length, in runes
+	Length uint
+	// Min and Max are the length limits given to CheckStrAll
+	Min uint
+	Max uint
+}
+
+// Error returns the english message for the violation
+func (v CheckStrViolation) Error() string {
+	return v.Message("")
+}
+
+// Message returns a meaningful message for the violation, considering the given idiom. The fallback is in english
+func (v CheckStrViolation) Message(idiom string) string {
+	return checkStrViolationMessage(idiom, v)
+}
+
+// Is makes errors.Is() match the violation against the rule's sentinel error, like ErrCheckStrNumbersDenied
+func (v CheckStrViolation) Is(target error) bool {
+	return checkStrSentinel(v.Code) == target
+}
+
+// CheckStrErrors is the list of violations found by CheckStrAll
+type CheckStrErrors []CheckStrViolation
+
+// Error returns all the violation messages, in english, separated by semicolons
+func (e CheckStrErrors) Error() string {
+	return strings.Join(e.Messages(""), "; ")
+}
+
+// Messages returns the violation messages considering the given idiom
+func (e CheckStrErrors) Messages(idiom string) []string {
+	a := make([]string, len(e))
+
+	for i, v := range e {
+		a[i] = v.Message(idiom)
+	}
+
+	return a
+}
+
+// Is makes errors.Is() true when any of the violations matches the given sentinel error
+func (e CheckStrErrors) Is(target error) bool {
+	for _, v := range e {
+		if v.Is(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Codes returns the CheckStr codes of all violations, in the order they were found
+func (e CheckStrErrors) Codes() []int8 {
+	a := make([]int8, len(e))
+
+	for i, v := range e {
+		a[i] = v.Code
+	}
+
+	return a
+}
+
+func checkStrSentinel(code int8) error {
+	switch code {
+	case CheckStrEmptyDenied:
+		return ErrCheckStrEmptyDenied
+	case CheckStrTooShort:
+		return ErrCheckStrTooShort
+	case CheckStrTooLong:
+		return ErrCheckStrTooLong
+	case CheckStrSpaceDenied:
+		return ErrCheckStrSpaceDenied
+	case CheckStrNubersDenied:
+		return ErrCheckStrNumbersDenied
+	case CheckStrLettersDenied:
+		return ErrCheckStrLettersDenied
+	case CheckStrSymbolsDenied:
+		return ErrCheckStrSymbolsDenied
+	case CheckStrMoreThanOneWordDenied:
+		return ErrCheckStrMoreThanOneWordDenied
+	case CheckStrUpperCaseDenied:
+		return ErrCheckStrUpperCaseDenied
+	case CheckStrLowercaseDenied:
+		return ErrCheckStrLowercaseDenied
+	case CheckStrUnicodeDenied:
+		return ErrCheckStrUnicodeDenied
+	case CheckStrNumbersNotFound:
+		return ErrCheckStrNumbersNotFound
+	case CheckStrLettersNotFound:
+		return ErrCheckStrLettersNotFound
+	case CheckStrSymbolsNotFound:
+		return ErrCheckStrSymbolsNotFound
+	case CheckStrMoreThanOneWordNotFound:
+		return ErrCheckStrMoreThanOneWordNotFound
+	case CheckStrUpperCaseNotFound:
+		return ErrCheckStrUpperCaseNotFound
+	case CheckStrLowercaseNotFound:
+		return ErrCheckStrLowercaseNotFound
+	}
+
+	return nil
+}
+
+// checkStrRule pairs a character class with its "deny" and "require" flags
+// positions returns the indexes of the runes that belong to the class
+type checkStrRule struct {
+	name        string
+	denyFlag    uint64
+	denyCode    int8
+	requireFlag uint64
+	requireCode int8
+	positions   func(runes []rune) []int
+}
+
+func runesMatching(match func(r rune) bool) func(runes []rune) []int {
+	return func(runes []rune) []int {
+		var positions []int
+
+		for i, r := range runes {
+			if match(r) {
+				positions = append(positions, i)
+			}
+		}
+
+		return positions
+	}
+}
+
+// extraWordsStart returns the positions where the second and next words begin
+func extraWordsStart(runes []rune) []int {
+	var (
+		positions []int
+		words     int
+		inWord    bool
+	)
+
+	for i, r := range runes {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+
+		if !inWord {
+			words++
+
+			if words > 1 {
+				positions = append(positions, i)
+			}
+		}
+
+		inWord = true
+	}
+
+	return positions
+}
+
+// checkStrRules follows the same order CheckStr evaluates the rules
+var checkStrRules = []checkStrRule{
+	{"spaces", CheckStrDenySpaces, CheckStrSpaceDenied, 0, 0, runesMatching(func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})},
+	{"numbers", CheckStrDenyNumbers, CheckStrNubersDenied, CheckStrRequireNumbers, CheckStrNumbersNotFound, runesMatching(unicode.IsNumber)},
+	{"letters", CheckStrDenyLetters, CheckStrLettersDenied, CheckStrRequireLetters, CheckStrLettersNotFound, runesMatching(unicode.IsLetter)},
+	{"symbols", CheckStrDenySymbols, CheckStrSymbolsDenied, CheckStrRequireSymbols, CheckStrSymbolsNotFound, runesMatching(func(r rune) bool {
+		return unicode.IsSymbol(r) || (!unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsSpace(r))
+	})},
+	{"more-than-one-word", CheckStrDenyMoreThanOneWord, CheckStrMoreThanOneWordDenied, CheckStrRequireMoreThanOneWord, CheckStrMoreThanOneWordNotFound, extraWordsStart},
+	{"uppercase", CheckStrDenyUpperCase, CheckStrUpperCaseDenied, CheckStrRequireUpperCase, CheckStrUpperCaseNotFound, runesMatching(unicode.IsUpper)},
+	{"lowercase", CheckStrDenyLowercase, CheckStrLowercaseDenied, CheckStrRequireLowercase, CheckStrLowercaseNotFound, runesMatching(unicode.IsLower)},
+	{"unicode", CheckStrDenyUnicode, CheckStrUnicodeDenied, 0, 0, runesMatching(func(r rune) bool {
+		return r > unicode.MaxASCII
+	})},
+}
+
+// CheckStrAll validates a string according the same rules of CheckStr, but instead of stopping at the first failure,
+// it collects all of them. It returns nil when the string is alright, or CheckStrErrors otherwise.
+// Example: errors.Is(CheckStrAll("abc 1", 0, 0, CheckStrDenySpaces|CheckStrDenyNumbers), ErrCheckStrNumbersDenied) returns true
+func CheckStrAll(seq string, minLen, maxLen uint, rules uint64) error {
+	strLen := uint(utf8.RuneCountInString(seq))
+
+	if seq == "" {
+		if rules&CheckStrAllowEmpty == CheckStrAllowEmpty {
+			return nil
+		}
+
+		return CheckStrErrors{{Rule: "deny-empty", Code: CheckStrEmptyDenied, Min: minLen, Max: maxLen}}
+	}
+
+	var violations CheckStrErrors
+
+	newViolation := func(rule string, code int8, positions []int) {
+		violations = append(violations, CheckStrViolation{Rule: rule, Code: code, Positions: positions, Length: strLen, Min: minLen, Max: maxLen})
+	}
+
+	if strLen < minLen {
+		newViolation("min-length", CheckStrTooShort, nil)
+	}
+
+	if maxLen > 0 && strLen > maxLen {
+		newViolation("max-length", CheckStrTooLong, nil)
+	}
+
+	runes := []rune(seq)
+
+	found := make([]bool, len(checkStrRules))
+
+	// "Deny" rules are evaluated first, and then "Require" rules, as CheckStr does
+	for i, rule := range checkStrRules {
+		positions := rule.positions(runes)
+
+		found[i] = len(positions) > 0
+
+		if rule.denyFlag != 0 && rules&rule.denyFlag == rule.denyFlag && found[i] {
+			newViolation("deny-"+rule.name, rule.denyCode, positions)
+		}
+	}
+
+	for i, rule := range checkStrRules {
+		if rule.requireFlag != 0 && rules&rule.requireFlag == rule.requireFlag && !found[i] {
+			newViolation("require-"+rule.name, rule.requireCode, nil)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return violations
+}
@@ -1,6 +1,8 @@
 package handy
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -66,3 +68,72 @@ func TestStrContainsEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckStrAll(t *testing.T) {
+	testlist := []struct {
+		summary        string
+		input          string
+		minLen         uint
+		maxLen         uint
+		flags          uint64
+		expectedOutput []int8
+	}{
+		{"perfect string", "perfect", 0, 10, CheckStrDenySpaces, nil},
+		{"allowed empty string", "", 0, 10, CheckStrAllowEmpty, nil},
+		{"empty string", "", 0, 10, 0, []int8{CheckStrEmptyDenied}},
+		{"too short and numbers", "a1", 3, 10, CheckStrDenyNumbers, []int8{CheckStrTooShort, CheckStrNubersDenied}},
+		{"everything wrong", "Tw0 wörds", 0, 5, CheckStrDenySpaces | CheckStrDenyNumbers | CheckStrDenyUnicode | CheckStrRequireSymbols, []int8{CheckStrTooLong, CheckStrSpaceDenied, CheckStrNubersDenied, CheckStrUnicodeDenied, CheckStrSymbolsNotFound}},
+		{"words and case", "ONE two", 0, 0, CheckStrDenyMoreThanOneWord | CheckStrDenyUpperCase | CheckStrRequireNumbers, []int8{CheckStrMoreThanOneWordDenied, CheckStrUpperCaseDenied, CheckStrNumbersNotFound}},
+	}
+
+	for _, tst := range testlist {
+		t.Run(tst.summary, func(t *testing.T) {
+			err := CheckStrAll(tst.input, tst.minLen, tst.maxLen, tst.flags)
+
+			if tst.expectedOutput == nil {
+				if err != nil {
+					t.Errorf("Failed with input %s, want nil and got %v instead", tst.input, err)
+				}
+
+				return
+			}
+
+			var ce CheckStrErrors
+
+			if !errors.As(err, &ce) {
+				t.Fatalf("Failed with input %s, want CheckStrErrors and got %v instead", tst.input, err)
+			}
+
+			if !reflect.DeepEqual(ce.Codes(), tst.expectedOutput) {
+				t.Errorf("Failed with input %s, want %v and got %v instead", tst.input, tst.expectedOutput, ce.Codes())
+			}
+
+			// The result of CheckStr must be the first violation
+			if r := CheckStr(tst.input, tst.minLen, tst.maxLen, tst.flags); r != tst.expectedOutput[0] {
+				t.Errorf("Failed with input %s, CheckStr returned %d and CheckStrAll %d", tst.input, r, tst.expectedOutput[0])
+			}
+		})
+	}
+}
+
+func TestCheckStrAllDetails(t *testing.T) {
+	err := CheckStrAll("a1 b2", 8, 0, CheckStrDenyNumbers|CheckStrDenySpaces)
+
+	if !errors.Is(err, ErrCheckStrNumbersDenied) || !errors.Is(err, ErrCheckStrTooShort) || errors.Is(err, ErrCheckStrTooLong) {
+		t.Errorf("errors.Is() doesn't match sentinels as expected: %v", err)
+	}
+
+	ce := err.(CheckStrErrors)
+
+	if !reflect.DeepEqual(ce[2].Positions, []int{1, 4}) || ce[2].Rule != "deny-numbers" {
+		t.Errorf("wrong violation details: %+v", ce[2])
+	}
+
+	if msg := ce[0].Message("bra"); msg != "Texto muito curto (mínimo de 8 caracteres)" {
+		t.Errorf("wrong translation: %s", msg)
+	}
+
+	if msg := ce[0].Error(); msg != "Text too short (minimum of 8 characters)" {
+		t.Errorf("wrong message: %s", msg)
+	}
+}
@@ -0,0 +1,126 @@
+package handy
+
+import "time"
+
+// DateRange is an interval of civil dates, including both Start and End
+// A range where End is before Start is empty
+type DateRange struct {
+	Start Date
+	End   Date
+}
+
+// NewDateRange returns the range between two dates, in any order
+func NewDateRange(a, b Date) DateRange {
+	if b.Before(a) {
+		a, b = b, a
+	}
+
+	return DateRange{Start: a, End: b}
+}
+
+// IsEmpty returns true when the range has no days, that is, End is before Start
+func (r DateRange) IsEmpty() bool {
+	return r.End.Before(r.Start)
+}
+
+// Days returns how many days the range has, counting both ends
+func (r DateRange) Days() int {
+	if r.IsEmpty() {
+		return 0
+	}
+
+	return r.Start.DaysUntil(r.End) + 1
+}
+
+// Contains returns true when the date is within the range, including both ends
+func (r DateRange) Contains(d Date) bool {
+	return !d.Before(r.Start) && !d.After(r.End)
+}
+
+// Overlaps returns true when both ranges have at least one day in common
+func (r DateRange) Overlaps(other DateRange) bool {
+	_, ok := r.Intersect(other)
+
+	return ok
+}
+
+// Intersect returns the days both ranges have in common, and false when there are none
+func (r DateRange) Intersect(other DateRange) (DateRange, bool) {
+	x := r
+
+	if other.Start.After(x.Start) {
+		x.Start = other.Start
+	}
+
+	if other.End.Before(x.End) {
+		x.End = other.End
+	}
+
+	if x.IsEmpty() {
+		return DateRange{}, false
+	}
+
+	return x, true
+}
+
+// SplitMonths splits the range by calendar month. The first and last pieces may be partial months
+// Example: 2019-01-15/2019-03-10 gives 2019-01-15/2019-01-31, 2019-02-01/2019-02-28 and 2019-03-01/2019-03-10
+func (r DateRange) SplitMonths() []DateRange {
+	return r.split(func(d Date) Date {
+		return NewDate(d.Year, d.Month+1, 1)
+	})
+}
+
+// SplitWeeks splits the range by week, each one beginning at weekStart, like time.Monday or time.Sunday
+// The first and last pieces may be partial weeks
+func (r DateRange) SplitWeeks(weekStart time.Weekday) []DateRange {
+	return r.split(func(d Date) Date {
+		return d.AddDays(7 - (int(d.Weekday())-int(weekStart)+7)%7)
+	})
+}
+
+// split cuts the range in pieces, next telling where the piece after the one containing d begins
+func (r DateRange) split(next func(d Date) Date) []DateRange {
+	var a []DateRange
+
+	for start := r.Start; !start.After(r.End); {
+		following := next(start)
+		piece := DateRange{Start: start, End: following.AddDays(-1)}
+
+		if piece.End.After(r.End) {
+			piece.End = r.End
+		}
+
+		a = append(a, piece)
+		start = following
+	}
+
+	return a
+}
+
+// Each calls fn for every date of the range, in order, until fn returns false
+func (r DateRange) Each(fn func(d Date) bool) {
+	for d := r.Start; !d.After(r.End); d = d.AddDays(1) {
+		if !fn(d) {
+			return
+		}
+	}
+}
+
+// Dates returns all the dates of the range, in order
+func (r DateRange) Dates() []Date {
+	a := make([]Date, 0, r.Days())
+
+	r.Each(func(d Date) bool {
+		a = append(a, d)
+
+		return true
+	})
+
+	return a
+}
+
+// String returns the range in ISO 8601 interval format, like "2019-01-01/2019-01-31"
+func (r DateRange) String() string {
+	return r.Start.String() + "/" + r.End.String()
+}
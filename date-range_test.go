@@ -0,0 +1,96 @@
+package handy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRangeIteration(t *testing.T) {
+	r := NewDateRange(NewDate(2020, 3, 2), NewDate(2020, 2, 27))
+
+	if r.Days() != 5 || r.String() != "2020-02-27/2020-03-02" {
+		t.Errorf("Test has failed!\n\tGot %d days in %s", r.Days(), r)
+	}
+
+	dates := r.Dates()
+
+	if len(dates) != 5 || dates[2] != NewDate(2020, 2, 29) || dates[4] != NewDate(2020, 3, 2) {
+		t.Errorf("Test has failed!\n\tGot: %v", dates)
+	}
+
+	count := 0
+
+	r.Each(func(d Date) bool {
+		count++
+
+		return d != NewDate(2020, 2, 28)
+	})
+
+	if count != 2 {
+		t.Errorf("Test has failed!\n\tExpected Each() to stop after 2 dates, got %d", count)
+	}
+
+	if !r.Contains(NewDate(2020, 2, 29)) || r.Contains(NewDate(2020, 3, 3)) || (DateRange{r.End, r.Start}).Days() != 0 {
+		t.Errorf("Test has failed! Contains() and empty ranges")
+	}
+}
+
+func TestDateRangeIntersect(t *testing.T) {
+	jan := DateRange{NewDate(2019, 1, 1), NewDate(2019, 1, 31)}
+
+	tcs := []struct {
+		summary        string
+		other          DateRange
+		expectedOutput string
+	}{
+		{"inside", DateRange{NewDate(2019, 1, 10), NewDate(2019, 1, 20)}, "2019-01-10/2019-01-20"},
+		{"partial", DateRange{NewDate(2018, 12, 20), NewDate(2019, 1, 5)}, "2019-01-01/2019-01-05"},
+		{"touching on the last day", DateRange{NewDate(2019, 1, 31), NewDate(2019, 2, 10)}, "2019-01-31/2019-01-31"},
+		{"disjoint", DateRange{NewDate(2019, 2, 1), NewDate(2019, 2, 10)}, ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr, ok := jan.Intersect(tc.other)
+
+			if ok != (tc.expectedOutput != "") || ok && tr.String() != tc.expectedOutput || jan.Overlaps(tc.other) != ok {
+				t.Errorf("Test has failed!\n\tExpected: %q, \n\tGot: %s, %v", tc.expectedOutput, tr, ok)
+			}
+		})
+	}
+}
+
+func TestDateRangeSplit(t *testing.T) {
+	r := DateRange{NewDate(2020, 1, 15), NewDate(2020, 3, 10)}
+
+	tcs := []struct {
+		summary        string
+		pieces         []DateRange
+		expectedOutput []string
+	}{
+		{"months", r.SplitMonths(), []string{"2020-01-15/2020-01-31", "2020-02-01/2020-02-29", "2020-03-01/2020-03-10"}},
+		{"weeks beginning on sunday", DateRange{NewDate(2019, 12, 31), NewDate(2020, 1, 14)}.SplitWeeks(time.Sunday), []string{"2019-12-31/2020-01-04", "2020-01-05/2020-01-11", "2020-01-12/2020-01-14"}},
+		{"weeks beginning on monday", DateRange{NewDate(2020, 1, 6), NewDate(2020, 1, 12)}.SplitWeeks(time.Monday), []string{"2020-01-06/2020-01-12"}},
+		{"empty", DateRange{r.End, r.Start}.SplitMonths(), nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			var tr []string
+
+			for _, p := range tc.pieces {
+				tr = append(tr, p.String())
+			}
+
+			if len(tr) != len(tc.expectedOutput) {
+				t.Fatalf("Test has failed!\n\tExpected: %v, \n\tGot: %v", tc.expectedOutput, tr)
+			}
+
+			for i := range tr {
+				if tr[i] != tc.expectedOutput[i] {
+					t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", tc.expectedOutput, tr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package handy
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const dateISOFormat = "yyyy-mm-dd"
+
+// Date is a civil date, without time of day nor time zone, like a birthdate or a due date
+// Its zero value is year 0, month 0 and day 0, that IsZero() reports. Use In() to turn it into time.Time at an explicit location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the date for the given year, month and day
+// Values out of range are normalized, as time.Date() does: NewDate(2019, 2, 30) is 2019-03-02
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 12, 0, 0, 0, time.UTC))
+}
+
+// DateOf returns the date of t in its own location, so 2019-01-01T23:00-03:00 is 2019-01-01, whatever the server time zone is
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+
+	return Date{y, m, d}
+}
+
+// DateToday returns the current date at the given location, according the package clock. See ClockSet()
+func DateToday(loc *time.Location) Date {
+	return packageHelpers().DateToday(loc)
+}
+
+// DateToday returns the current date at the given location, according the clock
+func (h ClockHelpers) DateToday(loc *time.Location) Date {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	return DateOf(h.clock.Now().In(loc))
+}
+
+// DateParse reads a date according handy's date format. See DateTimeParse() for the tokens
+// Any time of day in the string is discarded, and the date is taken as written, without time zone conversion
+func DateParse(s, format string) (Date, error) {
+	t, err := DateTimeParse(s, format)
+
+	if err != nil {
+		return Date{}, err
+	}
+
+	return DateOf(t), nil
+}
+
+// DateParseLocale reads a date as DateParse() does, with month and weekday names in the given language
+func DateParseLocale(s, format, locale string) (Date, error) {
+	t, err := DateTimeParseLocale(s, format, locale)
+
+	if err != nil {
+		return Date{}, err
+	}
+
+	return DateOf(t), nil
+}
+
+// IsZero returns true for the zero value, Date{}
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid returns true when the date exists in the calendar, so 2019-02-29 isn't valid
+func (d Date) IsValid() bool {
+	return NewDate(d.Year, d.Month, d.Day) == d
+}
+
+// In returns the date at midnight in the given location
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// noon is the date at noon UTC, used internally for calendar calculations
+func (d Date) noon() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 12, 0, 0, 0, time.UTC)
+}
+
+// String returns the date in ISO 8601 format, like "2019-12-31"
+func (d Date) String() string {
+	return d.Format(dateISOFormat)
+}
+
+// Format writes the date according handy's date format. See DateTimeParse() for the tokens. Time tokens write zeros
+func (d Date) Format(format string) string {
+	return DateTimeAsString(d.In(time.UTC), format)
+}
+
+// FormatLocale writes the date as Format() does, with month and weekday names in the given language
+func (d Date) FormatLocale(format, locale string) string {
+	return DateTimeAsStringLocale(d.In(time.UTC), format, locale)
+}
+
+// Weekday returns the day of week of the date
+func (d Date) Weekday() time.Weekday {
+	return d.noon().Weekday()
+}
+
+// AddDays returns the date n days later, or earlier when n is negative
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// AddMonths returns the date n months later, or earlier when n is negative
+// When the resulting month is shorter, the day is clamped to its last day: 2019-01-31 plus one month is 2019-02-28
+func (d Date) AddMonths(n int) Date {
+	first := NewDate(d.Year, d.Month+time.Month(n), 1)
+
+	if last := MonthLastDay(first.Year, int(first.Month)); d.Day > last {
+		first.Day = last
+	} else {
+		first.Day = d.Day
+	}
+
+	return first
+}
+
+// AddYears returns the date n years later, or earlier when n is negative. February 29th is clamped to the 28th
+func (d Date) AddYears(n int) Date {
+	return d.AddMonths(n * 12)
+}
+
+// DaysUntil returns how many days there are from d to other, negative when other is before d
+func (d Date) DaysUntil(other Date) int {
+	return int(other.noon().Sub(d.noon()).Hours() / 24)
+}
+
+// Compare returns -1, 0 or 1 when d is before, equal or after other
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return compareInts(d.Year, other.Year)
+	case d.Month != other.Month:
+		return compareInts(int(d.Month), int(other.Month))
+	}
+
+	return compareInts(d.Day, other.Day)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// Before returns true when d comes before other
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After returns true when d comes after other
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// MarshalText writes the date in ISO 8601 format. The zero date is written as an empty string
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads a date in ISO 8601 format, like "2019-12-31". An empty text is the zero date
+func (d *Date) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*d = Date{}
+
+		return nil
+	}
+
+	x, err := DateParse(string(b), dateISOFormat)
+
+	if err != nil {
+		return err
+	}
+
+	*d = x
+
+	return nil
+}
+
+// MarshalJSON writes the date as an ISO 8601 string, or null for the zero date
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON reads the date from an ISO 8601 string. Null and the empty string are the zero date
+func (d *Date) UnmarshalJSON(b []byte) error {
+	s := string(b)
+
+	if s == "null" {
+		*d = Date{}
+
+		return nil
+	}
+
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return fmt.Errorf("handy: date should be a JSON string, got %s", s)
+	}
+
+	return d.UnmarshalText([]byte(s[1 : len(s)-1]))
+}
+
+// Value implements driver.Valuer, writing the date as an ISO 8601 string, or NULL for the zero date
+func (d Date) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, reading the date from time.Time, as most drivers deliver DATE columns, or from text
+// A time.Time is taken by its own date, without time zone conversion
+func (d *Date) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Date{}
+		return nil
+	case time.Time:
+		*d = DateOf(v)
+		return nil
+	case []byte:
+		return d.scanText(string(v))
+	case string:
+		return d.scanText(v)
+	}
+
+	return fmt.Errorf("handy: can't scan %T into Date", src)
+}
+
+// scanText accepts "2019-12-31", and also timestamps like "2019-12-31 00:00:00", that some drivers deliver
+func (d *Date) scanText(s string) error {
+	if len(s) > 10 && (s[10] == ' ' || s[10] == 'T') {
+		s = s[:10]
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,115 @@
+package handy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateOf(t *testing.T) {
+	saoPaulo := time.FixedZone("-03", -3*3600)
+
+	// 23:00 in São Paulo is already the next day in UTC, but the civil date is the one written
+	dt := time.Date(2001, 10, 17, 23, 0, 0, 0, saoPaulo)
+
+	if d := DateOf(dt); d != NewDate(2001, 10, 17) {
+		t.Errorf("Test has failed!\n\tExpected: 2001-10-17, \n\tGot: %s", d)
+	}
+
+	if d, err := DateParse("2001-10-17T23:00:00-03:00", "yyyy-mm-dd'T'hh24:nn:ssz"); err != nil || d != NewDate(2001, 10, 17) {
+		t.Errorf("Test has failed!\n\tExpected: 2001-10-17, \n\tGot: %s, %v", d, err)
+	}
+
+	if tr := NewDate(2001, 10, 17).In(saoPaulo); !tr.Equal(time.Date(2001, 10, 17, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test has failed!\n\tGot: %s", tr)
+	}
+
+	now := time.Date(2019, 6, 1, 1, 0, 0, 0, time.UTC)
+
+	if d := WithClock(NewFakeClock(now)).DateToday(saoPaulo); d != NewDate(2019, 5, 31) {
+		t.Errorf("Test has failed!\n\tExpected: 2019-05-31, \n\tGot: %s", d)
+	}
+}
+
+func TestDateArithmetic(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		date           Date
+		expectedOutput Date
+	}{
+		{"normalization", NewDate(2019, 2, 30), Date{2019, 3, 2}},
+		{"add days across years", NewDate(2019, 12, 31).AddDays(1), Date{2020, 1, 1}},
+		{"subtract days", NewDate(2020, 3, 1).AddDays(-1), Date{2020, 2, 29}},
+		{"add month clamping", NewDate(2019, 1, 31).AddMonths(1), Date{2019, 2, 28}},
+		{"add month clamping leap year", NewDate(2020, 1, 31).AddMonths(1), Date{2020, 2, 29}},
+		{"subtract months across years", NewDate(2020, 3, 31).AddMonths(-4), Date{2019, 11, 30}},
+		{"add years from february 29th", NewDate(2020, 2, 29).AddYears(1), Date{2021, 2, 28}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if tc.date != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", tc.expectedOutput, tc.date)
+			}
+		})
+	}
+
+	a, b := NewDate(2019, 12, 25), NewDate(2020, 1, 1)
+
+	if a.DaysUntil(b) != 7 || b.DaysUntil(a) != -7 || !a.Before(b) || !b.After(a) || a.Compare(a) != 0 {
+		t.Errorf("Test has failed! Comparison between %s and %s", a, b)
+	}
+
+	if (Date{2019, 2, 29}).IsValid() || !(Date{2020, 2, 29}).IsValid() || !(Date{}).IsZero() {
+		t.Errorf("Test has failed! IsValid() and IsZero()")
+	}
+
+	if s := NewDate(2026, 10, 18).FormatLocale("ww, d 'de' mmmm 'de' yyyy", "pt-BR"); s != "domingo, 18 de outubro de 2026" {
+		t.Errorf("Test has failed!\n\tGot: %s", s)
+	}
+}
+
+func TestDateMarshaling(t *testing.T) {
+	var v struct {
+		Birth Date `json:"birth"`
+		Due   Date `json:"due"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"birth":"2001-10-17","due":null}`), &v); err != nil || v.Birth != NewDate(2001, 10, 17) || !v.Due.IsZero() {
+		t.Errorf("Test has failed!\n\tGot: %+v, %v", v, err)
+	}
+
+	if b, _ := json.Marshal(v); string(b) != `{"birth":"2001-10-17","due":null}` {
+		t.Errorf("Test has failed!\n\tGot: %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"birth":"2001-02-30"}`), &v); err == nil {
+		t.Errorf("Test has failed! An invalid date was accepted")
+	}
+
+	var d Date
+
+	tcs := []struct {
+		src            interface{}
+		expectedOutput Date
+	}{
+		{time.Date(2001, 10, 17, 0, 0, 0, 0, time.FixedZone("", -3*3600)), NewDate(2001, 10, 17)},
+		{[]byte("2001-10-17"), NewDate(2001, 10, 17)},
+		{"2001-10-17 00:00:00", NewDate(2001, 10, 17)},
+		{nil, Date{}},
+	}
+
+	for _, tc := range tcs {
+		if err := d.Scan(tc.src); err != nil || d != tc.expectedOutput {
+			t.Errorf("Test has failed!\n\tSource: %v, \n\tExpected: %s, \n\tGot: %s, %v", tc.src, tc.expectedOutput, d, err)
+		}
+	}
+
+	if err := d.Scan(42); err == nil {
+		t.Errorf("Test has failed! Scan(42) should fail")
+	}
+
+	if v, _ := NewDate(2001, 10, 17).Value(); v != "2001-10-17" {
+		t.Errorf("Test has failed!\n\tGot: %v", v)
+	}
+}
@@ -0,0 +1,795 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrDateTimeParse is wrapped by every error returned by DateTimeParse, to be tested with errors.Is()
+var ErrDateTimeParse = errors.New("handy: invalid date/time")
+
+// dateTimeTokenKind identifies each element of handy's date format language
+type dateTimeTokenKind uint8
+
+const (
+	dtLiteral dateTimeTokenKind = iota
+	dtYear4
+	dtYear2
+	dtISOYear
+	dtMonthName
+	dtMonthAbbr
+	dtMonth2
+	dtMonth
+	dtDayOfYear
+	dtDayOrdinal
+	dtDay2
+	dtDay
+	dtHour24Padded
+	dtHour24
+	dtHour12Padded
+	dtHour12
+	// dtHour12PaddedMarked and dtHour12Marked are hh and h, that imply the AM/PM marker. See dateTimeImplyMarker()
+	dtHour12PaddedMarked
+	dtHour12Marked
+	dtMinute2
+	dtMinute
+	dtSecond2
+	dtSecond
+	dtFraction
+	dtWeekdayName
+	dtWeekdayAbbr
+	dtISOWeek
+	dtAMPM
+	dtZoneName
+	dtZoneOffset
+	dtZoneOffsetColon
+)
+
+type dateTimeToken struct {
+	kind dateTimeTokenKind
+	// literal holds the text of dtLiteral tokens
+	literal string
+	// width holds the number of digits of dtFraction tokens
+	width int
+}
+
+// dateTimeTokenTable lists the tokens recognized by the tokenizer. Tokens are case insensitive.
+// At each position the longest token wins, so the order matters: "mmmm" must come before "mm", "hh24" before "hh" and so on.
+var dateTimeTokenTable = []struct {
+	token string
+	kind  dateTimeTokenKind
+}{
+	{"yyyy", dtYear4},
+	{"iyyy", dtISOYear},
+	{"mmmm", dtMonthName},
+	{"hh24", dtHour24Padded},
+	{"hh12", dtHour12Padded},
+	{"mmm", dtMonthAbbr},
+	{"ddd", dtDayOfYear},
+	{"h24", dtHour24},
+	{"h12", dtHour12},
+	{"zzz", dtZoneName},
+	{"yy", dtYear2},
+	{"mm", dtMonth2},
+	{"mi", dtMinute2},
+	{"do", dtDayOrdinal},
+	{"dd", dtDay2},
+	{"hh", dtHour12PaddedMarked},
+	{"nn", dtMinute2},
+	{"ss", dtSecond2},
+	{"ww", dtWeekdayName},
+	{"iw", dtISOWeek},
+	{"am", dtAMPM},
+	{"pm", dtAMPM},
+	{"zz", dtZoneOffset},
+	{"m", dtMonth},
+	{"d", dtDay},
+	{"h", dtHour12Marked},
+	{"n", dtMinute},
+	{"s", dtSecond},
+	{"w", dtWeekdayAbbr},
+	{"z", dtZoneOffsetColon},
+}
+
+// dateTimeTokenize splits a handy date format into tokens and literals
+// Text between single or double quotes is literal, as well as any character preceded by a backslash.
+// Two consecutive single quotes produce a single quote, inside or outside quoted text.
+func dateTimeTokenize(format string) []dateTimeToken {
+	var (
+		tokens  []dateTimeToken
+		literal strings.Builder
+	)
+
+	flush := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, dateTimeToken{kind: dtLiteral, literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(format); {
+		c := format[i]
+
+		switch {
+		case c == '\\' && i+1 < len(format):
+			literal.WriteByte(format[i+1])
+			i += 2
+			continue
+		case c == '\'' && i+1 < len(format) && format[i+1] == '\'':
+			literal.WriteByte('\'')
+			i += 2
+			continue
+		case c == '\'' || c == '"':
+			i++
+
+			for i < len(format) {
+				if format[i] == c {
+					if c == '\'' && i+1 < len(format) && format[i+1] == '\'' {
+						literal.WriteByte('\'')
+						i += 2
+						continue
+					}
+
+					i++
+					break
+				}
+
+				literal.WriteByte(format[i])
+				i++
+			}
+
+			continue
+		case c == 'f' || c == 'F':
+			flush()
+
+			width := 0
+
+			for i < len(format) && width < 9 && (format[i] == 'f' || format[i] == 'F') {
+				width++
+				i++
+			}
+
+			tokens = append(tokens, dateTimeToken{kind: dtFraction, width: width})
+			continue
+		}
+
+		matched := false
+
+		for _, t := range dateTimeTokenTable {
+			if len(format)-i >= len(t.token) && strings.EqualFold(format[i:i+len(t.token)], t.token) {
+				flush()
+				tokens = append(tokens, dateTimeToken{kind: t.kind})
+				i += len(t.token)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			literal.WriteByte(c)
+			i++
+		}
+	}
+
+	flush()
+
+	return dateTimeImplyMarker(tokens)
+}
+
+// dateTimeImplyMarker resolves hh and h, that write the AM/PM marker as they always did, unless the format has an explicit am or pm
+// The marker goes after the last time field, with a space, so "hh:nn" writes "05:30 PM". hh12 and h12 never imply it.
+func dateTimeImplyMarker(tokens []dateTimeToken) []dateTimeToken {
+	var (
+		marked   bool
+		explicit bool
+		last     = -1
+	)
+
+	for i, t := range tokens {
+		switch t.kind {
+		case dtHour12PaddedMarked:
+			tokens[i].kind = dtHour12Padded
+			marked = true
+		case dtHour12Marked:
+			tokens[i].kind = dtHour12
+			marked = true
+		case dtAMPM:
+			explicit = true
+		}
+
+		switch tokens[i].kind {
+		case dtHour12Padded, dtHour12, dtMinute2, dtMinute, dtSecond2, dtSecond, dtFraction:
+			last = i
+		}
+	}
+
+	if !marked || explicit {
+		return tokens
+	}
+
+	a := make([]dateTimeToken, 0, len(tokens)+2)
+	a = append(a, tokens[:last+1]...)
+	a = append(a, dateTimeToken{kind: dtLiteral, literal: " "}, dateTimeToken{kind: dtAMPM})
+
+	return append(a, tokens[last+1:]...)
+}
+
+// dateTimeHasFields returns false when the format is only literal text, which handy takes as a Go layout, like "2006-01-02"
+func dateTimeHasFields(tokens []dateTimeToken) bool {
+	for _, t := range tokens {
+		if t.kind != dtLiteral {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dateTimeFormatTokens(dt time.Time, tokens []dateTimeToken, names *DateTimeLocale) string {
+	var sb strings.Builder
+
+	pad := func(n, width int) {
+		s := strconv.Itoa(n)
+
+		for i := len(s); i < width; i++ {
+			sb.WriteByte('0')
+		}
+
+		sb.WriteString(s)
+	}
+
+	hour12 := dt.Hour() % 12
+
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	for _, t := range tokens {
+		switch t.kind {
+		case dtLiteral:
+			sb.WriteString(t.literal)
+		case dtYear4:
+			pad(dt.Year(), 4)
+		case dtYear2:
+			pad(dt.Year()%100, 2)
+		case dtISOYear:
+			y, _ := dt.ISOWeek()
+			pad(y, 4)
+		case dtMonthName:
+			sb.WriteString(names.Months[dt.Month()-1])
+		case dtMonthAbbr:
+			sb.WriteString(names.MonthsAbbr[dt.Month()-1])
+		case dtMonth2:
+			pad(int(dt.Month()), 2)
+		case dtMonth:
+			pad(int(dt.Month()), 1)
+		case dtDayOfYear:
+			pad(dt.YearDay(), 3)
+		case dtDayOrdinal:
+			sb.WriteString(names.ordinal(dt.Day()))
+		case dtDay2:
+			pad(dt.Day(), 2)
+		case dtDay:
+			pad(dt.Day(), 1)
+		case dtHour24Padded:
+			pad(dt.Hour(), 2)
+		case dtHour24:
+			pad(dt.Hour(), 1)
+		case dtHour12Padded:
+			pad(hour12, 2)
+		case dtHour12:
+			pad(hour12, 1)
+		case dtMinute2:
+			pad(dt.Minute(), 2)
+		case dtMinute:
+			pad(dt.Minute(), 1)
+		case dtSecond2:
+			pad(dt.Second(), 2)
+		case dtSecond:
+			pad(dt.Second(), 1)
+		case dtFraction:
+			sb.WriteString(fmt.Sprintf("%09d", dt.Nanosecond())[:t.width])
+		case dtWeekdayName:
+			sb.WriteString(names.Weekdays[dt.Weekday()])
+		case dtWeekdayAbbr:
+			sb.WriteString(names.WeekdaysAbbr[dt.Weekday()])
+		case dtISOWeek:
+			_, w := dt.ISOWeek()
+			pad(w, 2)
+		case dtAMPM:
+			if dt.Hour() < 12 {
+				sb.WriteString(names.am())
+			} else {
+				sb.WriteString(names.pm())
+			}
+		case dtZoneName:
+			name, _ := dt.Zone()
+			sb.WriteString(name)
+		case dtZoneOffset, dtZoneOffsetColon:
+			_, offset := dt.Zone()
+
+			if offset == 0 && t.kind == dtZoneOffsetColon {
+				sb.WriteByte('Z')
+				break
+			}
+
+			sign := byte('+')
+
+			if offset < 0 {
+				sign = '-'
+				offset = -offset
+			}
+
+			sb.WriteByte(sign)
+			pad(offset/3600, 2)
+
+			if t.kind == dtZoneOffsetColon {
+				sb.WriteByte(':')
+			}
+
+			pad(offset%3600/60, 2)
+		}
+	}
+
+	return sb.String()
+}
+
+// dateTimeFields accumulates what was read from the string while parsing
+type dateTimeFields struct {
+	year, month, day       int
+	hour, minute, second   int
+	nanosecond             int
+	dayOfYear              int
+	isoYear, isoWeek       int
+	weekday                int
+	yearSet, monthSet      bool
+	daySet, dayOfYearSet   bool
+	isoYearSet, isoWeekSet bool
+	weekdaySet, hour12     bool
+	amSet, pmSet           bool
+	zoneOffset             int
+	zoneOffsetSet          bool
+	zoneName               string
+}
+
+// dateTimeParser walks the value being parsed
+type dateTimeParser struct {
+	value string
+	pos   int
+}
+
+func (p *dateTimeParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d of %q", ErrDateTimeParse, fmt.Sprintf(format, a...), p.pos, p.value)
+}
+
+// digits reads at least min and at most max digits
+func (p *dateTimeParser) digits(min, max int, what string) (int, error) {
+	end := p.pos
+
+	for end < len(p.value) && end-p.pos < max && p.value[end] >= '0' && p.value[end] <= '9' {
+		end++
+	}
+
+	if end-p.pos < min {
+		return 0, p.errorf("expected %s", what)
+	}
+
+	n, _ := strconv.Atoi(p.value[p.pos:end])
+
+	p.pos = end
+
+	return n, nil
+}
+
+// name reads the longest of the given words, case insensitively, returning its index
+func (p *dateTimeParser) name(words []string, what string) (int, error) {
+	found, size := -1, 0
+
+	for i, w := range words {
+		if len(w) > size && len(p.value)-p.pos >= len(w) && strings.EqualFold(p.value[p.pos:p.pos+len(w)], w) {
+			found, size = i, len(w)
+		}
+	}
+
+	if found < 0 {
+		return 0, p.errorf("expected %s", what)
+	}
+
+	p.pos += size
+
+	return found, nil
+}
+
+// zoneOffset reads an offset like "-0300", or "-03:00" and "Z" when withColon is true
+func (p *dateTimeParser) zoneOffset(withColon bool) (int, error) {
+	if withColon && p.pos < len(p.value) && (p.value[p.pos] == 'Z' || p.value[p.pos] == 'z') {
+		p.pos++
+		return 0, nil
+	}
+
+	if p.pos >= len(p.value) || (p.value[p.pos] != '+' && p.value[p.pos] != '-') {
+		return 0, p.errorf("expected time zone offset")
+	}
+
+	sign := 1
+
+	if p.value[p.pos] == '-' {
+		sign = -1
+	}
+
+	p.pos++
+
+	hours, err := p.digits(2, 2, "time zone offset hours")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if withColon {
+		if p.pos >= len(p.value) || p.value[p.pos] != ':' {
+			return 0, p.errorf(`expected ":" in time zone offset`)
+		}
+
+		p.pos++
+	}
+
+	minutes, err := p.digits(2, 2, "time zone offset minutes")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if hours > 23 || minutes > 59 {
+		return 0, p.errorf("time zone offset out of range")
+	}
+
+	return sign * (hours*3600 + minutes*60), nil
+}
+
+// zoneName reads an abbreviation like "UTC", "BRT" or "-03"
+func (p *dateTimeParser) zoneName() (string, error) {
+	end := p.pos
+
+	if end < len(p.value) && (p.value[end] == '+' || p.value[end] == '-') {
+		end++
+
+		for end < len(p.value) && p.value[end] >= '0' && p.value[end] <= '9' {
+			end++
+		}
+	} else {
+		for end < len(p.value) && (p.value[end] >= 'A' && p.value[end] <= 'Z' || p.value[end] >= 'a' && p.value[end] <= 'z') {
+			end++
+		}
+	}
+
+	if end-p.pos < 2 {
+		return "", p.errorf("expected time zone name")
+	}
+
+	name := p.value[p.pos:end]
+
+	p.pos = end
+
+	return name, nil
+}
+
+func dateTimeParseTokens(value string, tokens []dateTimeToken, names *DateTimeLocale) (time.Time, error) {
+	p := &dateTimeParser{value: value}
+
+	f := dateTimeFields{month: 1, day: 1}
+
+	var err error
+
+	for _, t := range tokens {
+		switch t.kind {
+		case dtLiteral:
+			if !strings.HasPrefix(value[p.pos:], t.literal) {
+				return time.Time{}, p.errorf("expected %q", t.literal)
+			}
+
+			p.pos += len(t.literal)
+		case dtYear4:
+			f.year, err = p.digits(4, 4, "four digits year")
+			f.yearSet = true
+		case dtYear2:
+			f.year, err = p.digits(2, 2, "two digits year")
+			f.yearSet = true
+
+			// The same pivot Go uses: 69-99 goes to the 1900s, 00-68 to the 2000s
+			if f.year >= 69 {
+				f.year += 1900
+			} else {
+				f.year += 2000
+			}
+		case dtISOYear:
+			f.isoYear, err = p.digits(4, 4, "four digits ISO year")
+			f.isoYearSet = true
+		case dtMonthName, dtMonthAbbr:
+			words := names.MonthsAbbr[:]
+
+			if t.kind == dtMonthName {
+				words = names.Months[:]
+			}
+
+			f.month, err = p.name(words, "month name")
+			f.month++
+			f.monthSet = true
+		case dtMonth2:
+			f.month, err = p.digits(2, 2, "two digits month")
+			f.monthSet = true
+		case dtMonth:
+			f.month, err = p.digits(1, 2, "month")
+			f.monthSet = true
+		case dtDayOfYear:
+			f.dayOfYear, err = p.digits(3, 3, "three digits day of year")
+			f.dayOfYearSet = true
+		case dtDayOrdinal:
+			start := p.pos
+
+			if f.day, err = p.digits(1, 2, "ordinal day"); err == nil {
+				ordinal := names.ordinal(f.day)
+				read := value[start:p.pos]
+
+				if strings.HasPrefix(ordinal, read) && len(value)-start >= len(ordinal) && strings.EqualFold(value[start:start+len(ordinal)], ordinal) {
+					p.pos = start + len(ordinal)
+				} else {
+					err = p.errorf("expected ordinal day %q", ordinal)
+				}
+			}
+
+			f.daySet = true
+		case dtDay2:
+			f.day, err = p.digits(2, 2, "two digits day")
+			f.daySet = true
+		case dtDay:
+			f.day, err = p.digits(1, 2, "day")
+			f.daySet = true
+		case dtHour24Padded:
+			f.hour, err = p.digits(2, 2, "two digits hour")
+		case dtHour24:
+			f.hour, err = p.digits(1, 2, "hour")
+		case dtHour12Padded:
+			f.hour, err = p.digits(2, 2, "two digits hour")
+			f.hour12 = true
+		case dtHour12:
+			f.hour, err = p.digits(1, 2, "hour")
+			f.hour12 = true
+		case dtMinute2:
+			f.minute, err = p.digits(2, 2, "two digits minute")
+		case dtMinute:
+			f.minute, err = p.digits(1, 2, "minute")
+		case dtSecond2:
+			f.second, err = p.digits(2, 2, "two digits second")
+		case dtSecond:
+			f.second, err = p.digits(1, 2, "second")
+		case dtFraction:
+			var n int
+
+			if n, err = p.digits(t.width, t.width, fmt.Sprintf("%d digits fraction of second", t.width)); err == nil {
+				for i := t.width; i < 9; i++ {
+					n *= 10
+				}
+
+				f.nanosecond = n
+			}
+		case dtWeekdayName, dtWeekdayAbbr:
+			words := names.WeekdaysAbbr[:]
+
+			if t.kind == dtWeekdayName {
+				words = names.Weekdays[:]
+			}
+
+			f.weekday, err = p.name(words, "weekday name")
+			f.weekdaySet = true
+		case dtISOWeek:
+			f.isoWeek, err = p.digits(2, 2, "two digits ISO week")
+			f.isoWeekSet = true
+		case dtAMPM:
+			var i int
+
+			if i, err = p.name([]string{names.am(), names.pm()}, names.am()+" or "+names.pm()); err == nil {
+				f.amSet, f.pmSet = i == 0, i == 1
+			}
+		case dtZoneOffset, dtZoneOffsetColon:
+			f.zoneOffset, err = p.zoneOffset(t.kind == dtZoneOffsetColon)
+			f.zoneOffsetSet = true
+		case dtZoneName:
+			f.zoneName, err = p.zoneName()
+		}
+
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if p.pos < len(value) {
+		return time.Time{}, p.errorf("unexpected text %q", value[p.pos:])
+	}
+
+	return f.time(p)
+}
+
+// time validates the fields read and assembles them as time.Time
+func (f *dateTimeFields) time(p *dateTimeParser) (time.Time, error) {
+	if f.isoWeekSet {
+		year := f.year
+
+		if f.isoYearSet {
+			year = f.isoYear
+		}
+
+		weekday := time.Monday
+
+		if f.weekdaySet {
+			weekday = time.Weekday(f.weekday)
+		}
+
+		// January 4th always belongs to the first ISO week
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		dt := monday.AddDate(0, 0, (f.isoWeek-1)*7+(int(weekday)+6)%7)
+
+		if y, w := dt.ISOWeek(); f.isoWeek < 1 || y != year || w != f.isoWeek {
+			return time.Time{}, p.errorf("ISO week %d doesn't exist in %d", f.isoWeek, year)
+		}
+
+		if f.monthSet && int(dt.Month()) != f.month || f.daySet && dt.Day() != f.day {
+			return time.Time{}, p.errorf("ISO week %d doesn't match the date", f.isoWeek)
+		}
+
+		f.year, f.month, f.day = dt.Year(), int(dt.Month()), dt.Day()
+	} else if f.isoYearSet && !f.yearSet {
+		f.year = f.isoYear
+	}
+
+	if f.month < 1 || f.month > 12 {
+		return time.Time{}, p.errorf("month %d out of range", f.month)
+	}
+
+	if f.dayOfYearSet {
+		daysInYear := time.Date(f.year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+
+		if f.dayOfYear < 1 || f.dayOfYear > daysInYear {
+			return time.Time{}, p.errorf("day of year %d out of range", f.dayOfYear)
+		}
+
+		dt := time.Date(f.year, time.January, f.dayOfYear, 0, 0, 0, 0, time.UTC)
+
+		if f.monthSet && int(dt.Month()) != f.month || f.daySet && dt.Day() != f.day {
+			return time.Time{}, p.errorf("day of year %d doesn't match the date", f.dayOfYear)
+		}
+
+		f.month, f.day = int(dt.Month()), dt.Day()
+	}
+
+	if daysInMonth := time.Date(f.year, time.Month(f.month)+1, 0, 0, 0, 0, 0, time.UTC).Day(); f.day < 1 || f.day > daysInMonth {
+		return time.Time{}, p.errorf("day %d out of range", f.day)
+	}
+
+	if f.hour12 {
+		if f.hour < 1 || f.hour > 12 {
+			return time.Time{}, p.errorf("hour %d out of range", f.hour)
+		}
+
+		if f.pmSet && f.hour < 12 {
+			f.hour += 12
+		} else if f.amSet && f.hour == 12 {
+			f.hour = 0
+		}
+	}
+
+	if f.hour > 23 {
+		return time.Time{}, p.errorf("hour %d out of range", f.hour)
+	}
+
+	if f.minute > 59 {
+		return time.Time{}, p.errorf("minute %d out of range", f.minute)
+	}
+
+	if f.second > 59 {
+		return time.Time{}, p.errorf("second %d out of range", f.second)
+	}
+
+	return time.Date(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second, f.nanosecond, f.location()), nil
+}
+
+// location resolves the time zone read, which is UTC when no zone was given
+func (f *dateTimeFields) location() *time.Location {
+	if f.zoneOffsetSet {
+		if f.zoneOffset == 0 && (f.zoneName == "" || f.zoneName == "UTC") {
+			return time.UTC
+		}
+
+		return time.FixedZone(f.zoneName, f.zoneOffset)
+	}
+
+	switch strings.ToUpper(f.zoneName) {
+	case "":
+		return time.UTC
+	case "UTC", "GMT", "Z":
+		return time.UTC
+	}
+
+	// Numeric abbreviations, like "-03", carry their own offset
+	if f.zoneName[0] == '+' || f.zoneName[0] == '-' {
+		if n, err := strconv.Atoi(f.zoneName); err == nil {
+			if len(f.zoneName) > 3 {
+				return time.FixedZone(f.zoneName, (n/100)*3600+(n%100)*60)
+			}
+
+			return time.FixedZone(f.zoneName, n*3600)
+		}
+	}
+
+	// As Go's time.Parse does, a known local abbreviation resolves to the local zone, and others get a zero offset
+	local := time.Date(f.year, time.Month(f.month), f.day, f.hour, f.minute, f.second, f.nanosecond, time.Local)
+
+	if name, _ := local.Zone(); name == f.zoneName {
+		return time.Local
+	}
+
+	return time.FixedZone(f.zoneName, 0)
+}
+
+// DateTimeParse converts a date-time string according handy's date format, returning an error explaining what's wrong
+// The format tokens are case insensitive, and the longest token wins where they overlap:
+//
+//	yyyy, yy        four and two digits year. iyyy is the four digits ISO 8601 week-numbering year
+//	mmmm, mmm       month name and abbreviation, like "January" and "Jan"
+//	mm, m           month with and without leading zero
+//	dd, d, do       day of month with and without leading zero, and ordinal day, like "1st"
+//	ddd             three digits day of year, like "032"
+//	ww, w           weekday name and abbreviation, like "Monday" and "Mon"
+//	iw              two digits ISO 8601 week number
+//	hh24, h24       hour from 0 to 23, with and without leading zero
+//	hh, h           hour from 1 to 12, with and without leading zero, and the AM/PM marker after the last time field:
+//	                "hh:nn" is "05:30 PM". When the format has am or pm, the marker goes there instead: "hh:nn am"
+//	hh12, h12       hour from 1 to 12, with and without leading zero, without any AM/PM marker
+//	nn, n, mi       minute with and without leading zero. mi is the same as nn
+//	ss, s           second with and without leading zero
+//	f to fffffffff  fraction of second, with as many digits as f letters
+//	z, zz, zzz      time zone offset, like "-03:00" or "Z"; offset without colon, like "-0300"; and zone name, like "UTC"
+//
+// Text between single or double quotes, or any character preceded by a backslash, is literal: "dd 'de' mmmm".
+// A format without any token is taken as a Go layout, like "2006-01-02".
+// Without a time zone in the format, the result is in UTC.
+func DateTimeParse(s, format string) (time.Time, error) {
+	return dateTimeParse(s, format, dateTimeLocale(CatalogFallbackLanguage))
+}
+
+func dateTimeParse(s, format string, names *DateTimeLocale) (time.Time, error) {
+	if format == "" {
+		return time.Time{}, fmt.Errorf("%w: empty format", ErrDateTimeParse)
+	}
+
+	tokens := dateTimeTokenize(format)
+
+	if !dateTimeHasFields(tokens) {
+		t, err := time.Parse(format, s)
+
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %v", ErrDateTimeParse, err)
+		}
+
+		return t, nil
+	}
+
+	return dateTimeParseTokens(s, tokens, names)
+}
+
+func dateTimeFormat(dt time.Time, format string, names *DateTimeLocale) string {
+	if format == "" {
+		return ""
+	}
+
+	tokens := dateTimeTokenize(format)
+
+	if !dateTimeHasFields(tokens) {
+		return dt.Format(format)
+	}
+
+	return dateTimeFormatTokens(dt, tokens, names)
+}
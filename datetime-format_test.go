@@ -0,0 +1,158 @@
+package handy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDateTimeAsStringTokens(t *testing.T) {
+	dt := time.Date(2019, 2, 3, 14, 5, 9, 123456789, time.FixedZone("BRT", -3*3600))
+
+	tcs := []struct {
+		summary        string
+		format         string
+		expectedOutput string
+	}{
+		{"minutes with mi", "hh24:mi", "14:05"},
+		{"minutes with nn", "h24:n", "14:5"},
+		{"twelve hours clock", "hh:nn:ss am", "02:05:09 PM"},
+		{"twelve hours without padding", "h pm", "2 PM"},
+		{"twelve hours implied marker", "hh:nn", "02:05 PM"},
+		{"twelve hours implied marker after seconds", "dd/mm h:nn:ss.ff", "03/02 2:05:09.12 PM"},
+		{"twelve hours without marker", "hh12:nn", "02:05"},
+		{"twelve hours without marker nor padding", "h12", "2"},
+		{"month and weekday names", "ww, mmmm d", "Sunday, February 3"},
+		{"abbreviations", "w, d mmm yy", "Sun, 3 Feb 19"},
+		{"quoted literals", "dd 'de' mmmm 'de' yyyy", "03 de February de 2019"},
+		{"double quoted literals", `"day" d "at" hh24"h"`, "day 3 at 14h"},
+		{"escaped characters", `\d\a\y d`, "day 3"},
+		{"single quote", "hh24 o''clock", "14 o'clock"},
+		{"literals keep their case", "yyyy-mm-dd'T'hh24:nn", "2019-02-03T14:05"},
+		{"uppercase tokens", "DD/MM/YYYY HH24:NN", "03/02/2019 14:05"},
+		{"fractional seconds", "ss.f ss.fff ss.fffffffff", "09.1 09.123 09.123456789"},
+		{"time zone offset", "z zz", "-03:00 -0300"},
+		{"time zone name", "zzz", "BRT"},
+		{"ordinal day", "mmmm do", "February 3rd"},
+		{"day of year", "yyyy-ddd", "2019-034"},
+		{"ISO week", "iyyy-'W'iw", "2019-W05"},
+		{"go layout", "2006-01-02", "2019-02-03"},
+		{"empty format", "", ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := DateTimeAsString(dt, tc.format)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, \n\tFormat: %s", tc.expectedOutput, tr, tc.format)
+			}
+		})
+	}
+}
+
+func TestDateTimeOrdinals(t *testing.T) {
+	tcs := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 23: "23rd", 31: "31st"}
+
+	for day, expected := range tcs {
+		if tr := DateTimeAsString(time.Date(2019, 1, day, 0, 0, 0, 0, time.UTC), "do"); tr != expected {
+			t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", expected, tr)
+		}
+	}
+}
+
+func TestDateTimeParse(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		value          string
+		format         string
+		expectedOutput time.Time
+	}{
+		{"date", "31/10/2018", "dd/mm/yyyy", time.Date(2018, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{"unpadded date", "1/2/2018", "d/m/yyyy", time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"two digits year", "01/02/70", "dd/mm/yy", time.Date(1970, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"month name case insensitive", "march 5, 2020", "mmmm d, yyyy", time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"twelve hours AM", "12:30 AM", "hh:mi am", time.Date(0, 1, 1, 0, 30, 0, 0, time.UTC)},
+		{"twelve hours PM", "01:30 pm", "hh:mi pm", time.Date(0, 1, 1, 13, 30, 0, 0, time.UTC)},
+		{"twelve hours implied marker", "05:30 PM", "hh:nn", time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC)},
+		{"twelve hours without marker", "05:30", "hh12:nn", time.Date(0, 1, 1, 5, 30, 0, 0, time.UTC)},
+		{"fraction", "10:20:30.25", "hh24:nn:ss.ff", time.Date(0, 1, 1, 10, 20, 30, 250000000, time.UTC)},
+		{"offset", "2018-10-31T10:00:00-03:00", "yyyy-mm-dd'T'hh24:nn:ssz", time.Date(2018, 10, 31, 13, 0, 0, 0, time.UTC)},
+		{"offset Z", "2018-10-31T10:00:00Z", "yyyy-mm-dd'T'hh24:nn:ssz", time.Date(2018, 10, 31, 10, 0, 0, 0, time.UTC)},
+		{"numeric zone name", "2018-10-31 10:00 -03", "yyyy-mm-dd hh24:nn zzz", time.Date(2018, 10, 31, 13, 0, 0, 0, time.UTC)},
+		{"ordinal", "October 31st, 2018", "mmmm do, yyyy", time.Date(2018, 10, 31, 0, 0, 0, 0, time.UTC)},
+		{"day of year", "2020-366", "yyyy-ddd", time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"ISO week", "2020-W53-Sun", "iyyy-'W'iw-w", time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"ISO week defaults to monday", "2019-W01", "iyyy-'W'iw", time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"go layout", "2018-12-31", "2006-01-02", time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr, err := DateTimeParse(tc.value, tc.format)
+
+			if err != nil || !tr.Equal(tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, %v, \n\tValue: %s, \n\tFormat: %s", tc.expectedOutput, tr, err, tc.value, tc.format)
+			}
+		})
+	}
+}
+
+func TestDateTimeParseErrors(t *testing.T) {
+	tcs := []struct {
+		summary string
+		value   string
+		format  string
+	}{
+		{"empty format", "2018-01-01", ""},
+		{"invalid day", "2018-02-29", "yyyy-mm-dd"},
+		{"invalid month", "2018-13-01", "yyyy-mm-dd"},
+		{"missing digits", "2018-1-01", "yyyy-mm-dd"},
+		{"extra text", "2018-01-01 10:00", "yyyy-mm-dd"},
+		{"literal mismatch", "2018/01/01", "yyyy-mm-dd"},
+		{"twelve hours out of range", "13:00 PM", "hh:nn am"},
+		{"minute out of range", "10:60", "hh24:nn"},
+		{"wrong ordinal", "October 31th", "mmmm do"},
+		{"day of year out of range", "2019-366", "yyyy-ddd"},
+		{"ISO week out of range", "2019-W53", "iyyy-'W'iw"},
+		{"unknown month name", "Foo 2018", "mmm yyyy"},
+		{"bad offset", "10:00 -3", "hh24:nn z"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			_, err := DateTimeParse(tc.value, tc.format)
+
+			if !errors.Is(err, ErrDateTimeParse) {
+				t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v, \n\tValue: %s, \n\tFormat: %s", ErrDateTimeParse, err, tc.value, tc.format)
+			}
+		})
+	}
+}
+
+func TestDateTimeRoundTrip(t *testing.T) {
+	dts := []time.Time{
+		time.Date(2019, 2, 3, 14, 5, 9, 123456789, time.FixedZone("", -3*3600)),
+		time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 1, 23, 59, 59, 999000000, time.FixedZone("", 5*3600+30*60)),
+		time.Date(1999, 7, 22, 12, 0, 0, 0, time.UTC),
+	}
+
+	formats := []string{
+		"yyyy-mm-dd'T'hh24:nn:ss.fffffffffz",
+		"ww, do mmmm yyyy h:mi:ss.fffffffff am zz",
+		"w d mmm yy hh:nn:ss.fffffffff pm z",
+		"yyyy-ddd hh24:nn:ss.fffffffffz",
+		"iyyy-'W'iw-ww hh24:nn:ss.fffffffffz",
+	}
+
+	for _, dt := range dts {
+		for _, format := range formats {
+			s := DateTimeAsString(dt, format)
+
+			if tr := StringAsDateTime(s, format); !tr.Equal(dt) {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, \n\tString: %s, \n\tFormat: %s", dt, tr, s, format)
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package handy
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DateTimeLocale holds the words used by the date format tokens that produce names: mmmm, mmm, ww, w, do and am/pm
+type DateTimeLocale struct {
+	// Months holds the month names, from january to december
+	Months [12]string
+	// MonthsAbbr holds the abbreviated month names, from january to december
+	MonthsAbbr [12]string
+	// Weekdays holds the weekday names, from sunday to saturday, following time.Weekday
+	Weekdays [7]string
+	// WeekdaysAbbr holds the abbreviated weekday names, from sunday to saturday
+	WeekdaysAbbr [7]string
+	// AM and PM are the twelve hours clock markers. Default is "AM" and "PM"
+	AM, PM string
+	// Ordinal writes the day of month as an ordinal, like "1st" or "1º". When nil, the day is written as a plain number
+	Ordinal func(day int) string
+}
+
+func (l *DateTimeLocale) ordinal(day int) string {
+	if l.Ordinal == nil {
+		return strconv.Itoa(day)
+	}
+
+	return l.Ordinal(day)
+}
+
+func (l *DateTimeLocale) am() string {
+	if l.AM == "" {
+		return "AM"
+	}
+
+	return l.AM
+}
+
+func (l *DateTimeLocale) pm() string {
+	if l.PM == "" {
+		return "PM"
+	}
+
+	return l.PM
+}
+
+var dateTimeLocales = struct {
+	sync.RWMutex
+	m map[string]*DateTimeLocale
+}{m: map[string]*DateTimeLocale{}}
+
+func init() {
+	for tag, l := range dateTimeBuiltinLocales {
+		DateTimeLocaleRegister(tag, l)
+	}
+}
+
+// DateTimeLocaleRegister adds, or replaces, the names used for the given BCP-47 language tag, like "pt-BR" or "es"
+func DateTimeLocaleRegister(tag string, locale DateTimeLocale) {
+	dateTimeLocales.Lock()
+	defer dateTimeLocales.Unlock()
+
+	dateTimeLocales.m[CatalogCanonicalTag(tag)] = &locale
+}
+
+// DateTimeLocaleGet returns the names used for the given language tag
+// The lookup follows the same fallback chain of DefaultCatalog, like "pt-BR" → "pt" → "en", and accepts its aliases, like "bra"
+func DateTimeLocaleGet(tag string) DateTimeLocale {
+	return *dateTimeLocale(tag)
+}
+
+func dateTimeLocale(tag string) *DateTimeLocale {
+	dateTimeLocales.RLock()
+	defer dateTimeLocales.RUnlock()
+
+	for _, lang := range DefaultCatalog.FallbackChain(tag) {
+		if l, ok := dateTimeLocales.m[lang]; ok {
+			return l
+		}
+	}
+
+	return dateTimeLocales.m[CatalogFallbackLanguage]
+}
+
+// DateTimeAsStringLocale formats time.Time variables as strings, writing month and weekday names in the given language
+// Example: DateTimeAsStringLocale(dt, "ww, d 'de' mmmm 'de' yyyy", "pt-BR") returns "sábado, 18 de outubro de 2026"
+func DateTimeAsStringLocale(dt time.Time, format, locale string) string {
+	return dateTimeFormat(dt, format, dateTimeLocale(locale))
+}
+
+// StringAsDateTimeLocale converts a date-time string, reading month and weekday names in the given language
+// It returns a zero time when the string doesn't match the format. Use DateTimeParseLocale() to know why.
+func StringAsDateTimeLocale(s, format, locale string) time.Time {
+	t, _ := DateTimeParseLocale(s, format, locale)
+
+	return t
+}
+
+// DateTimeParseLocale works like DateTimeParse(), reading month and weekday names in the given language
+// Names are compared case insensitively, so "Outubro" and "OUTUBRO" are accepted for "outubro"
+func DateTimeParseLocale(s, format, locale string) (time.Time, error) {
+	return dateTimeParse(s, format, dateTimeLocale(locale))
+}
+
+// englishOrdinal returns the day followed by its english ordinal suffix, like "1st", "12th" or "23rd"
+func englishOrdinal(day int) string {
+	suffix := "th"
+
+	if day%100 < 11 || day%100 > 13 {
+		switch day % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return strconv.Itoa(day) + suffix
+}
+
+// firstDayOrdinal writes only the first day of month as an ordinal, as in "1º de janeiro" or "1er janvier"
+func firstDayOrdinal(suffix string) func(day int) string {
+	return func(day int) string {
+		if day == 1 {
+			return "1" + suffix
+		}
+
+		return strconv.Itoa(day)
+	}
+}
+
+var dateTimeBuiltinLocales = map[string]DateTimeLocale{
+	"en": {
+		Months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		MonthsAbbr:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		Weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		WeekdaysAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		Ordinal:      englishOrdinal,
+	},
+	"pt": {
+		Months:       [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		MonthsAbbr:   [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		Weekdays:     [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		WeekdaysAbbr: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		Ordinal:      firstDayOrdinal("º"),
+	},
+	"es": {
+		Months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		MonthsAbbr:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		Weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		WeekdaysAbbr: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	},
+	"fr": {
+		Months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		MonthsAbbr:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+		Weekdays:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		WeekdaysAbbr: [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+		Ordinal:      firstDayOrdinal("er"),
+	},
+	"de": {
+		Months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		MonthsAbbr:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		Weekdays:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		WeekdaysAbbr: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Ordinal: func(day int) string {
+			return strconv.Itoa(day) + "."
+		},
+	},
+	"it": {
+		Months:       [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		MonthsAbbr:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		Weekdays:     [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		WeekdaysAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		Ordinal:      firstDayOrdinal("º"),
+	},
+}
@@ -0,0 +1,89 @@
+package handy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTimeAsStringLocale(t *testing.T) {
+	dt := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	first := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+
+	tcs := []struct {
+		summary        string
+		time           time.Time
+		format         string
+		locale         string
+		expectedOutput string
+	}{
+		{"brazilian invoice", dt, "d 'de' mmmm 'de' yyyy", "pt-BR", "18 de outubro de 2026"},
+		{"brazilian weekday", dt, "ww", "pt-BR", "domingo"},
+		{"brazilian abbreviations", dt, "w, dd/mmm", "pt-BR", "dom, 18/out"},
+		{"legacy idiom code", dt, "mmmm", "bra", "outubro"},
+		{"portuguese ordinal", first, "do 'de' mmmm", "pt", "1º de outubro"},
+		{"spanish", dt, "ww d 'de' mmmm", "es", "domingo 18 de octubre"},
+		{"french", first, "ww do mmmm yyyy", "fr-FR", "jeudi 1er octobre 2026"},
+		{"german", dt, "ww, do mmmm yyyy", "de", "Sonntag, 18. Oktober 2026"},
+		{"italian", dt, "ww d mmmm", "it", "domenica 18 ottobre"},
+		{"unknown falls back to english", dt, "ww, mmmm do", "xx", "Sunday, October 18th"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := DateTimeAsStringLocale(tc.time, tc.format, tc.locale)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, \n\tFormat: %s, \n\tLocale: %s", tc.expectedOutput, tr, tc.format, tc.locale)
+			}
+		})
+	}
+}
+
+func TestStringAsDateTimeLocale(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		value          string
+		format         string
+		locale         string
+		expectedOutput time.Time
+	}{
+		{"brazilian invoice", "18 de outubro de 2026", "d 'de' mmmm 'de' yyyy", "pt-BR", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"accented name in uppercase", "SÁBADO, 17 DE OUTUBRO", "ww, d 'DE' mmmm", "pt-BR", time.Date(0, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"accented month", "1º de março de 2020", "do 'de' mmmm 'de' yyyy", "pt", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"french abbreviation", "3 févr 2021", "d mmm yyyy", "fr", time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{"german", "3. März 2021", "do mmmm yyyy", "de", time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{"english name in another locale", "3 March 2021", "d mmmm yyyy", "pt-BR", time.Time{}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := StringAsDateTimeLocale(tc.value, tc.format, tc.locale)
+
+			if !tr.Equal(tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, \n\tValue: %s, \n\tLocale: %s", tc.expectedOutput, tr, tc.value, tc.locale)
+			}
+		})
+	}
+}
+
+func TestDateTimeLocaleRegister(t *testing.T) {
+	eo := DateTimeLocaleGet("en")
+	eo.Months[9] = "oktobro"
+	eo.Weekdays[0] = "dimanĉo"
+
+	DateTimeLocaleRegister("eo", eo)
+
+	dt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	if s := DateTimeAsStringLocale(dt, "ww d mmmm", "eo"); s != "dimanĉo 18 oktobro" {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", "dimanĉo 18 oktobro", s)
+	}
+
+	if tr := StringAsDateTimeLocale("18 oktobro 2026", "d mmmm yyyy", "eo"); !tr.Equal(dt) {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", dt, tr)
+	}
+
+	if s := DateTimeAsString(dt, "mmmm"); s != "October" {
+		t.Errorf("Test has failed! Registering a locale changed english names: %s", s)
+	}
+}
@@ -1,104 +1,49 @@
 package handy
 
 import (
-	"strings"
 	"time"
 )
 
-// golangDateFormat translate handy's arbitrary date format to Go's eccentric format
-func golangDateTimeFormat(format string) string {
-	if format == "" {
-		return ""
-	}
-
-	newFormat := strings.ToLower(format)
-
-	newFormat = strings.Replace(newFormat, "yyyy", "2006", -1)
-	newFormat = strings.Replace(newFormat, "yy", "06", -1)
-	newFormat = strings.Replace(newFormat, "mmmm", "January", -1)
-	newFormat = strings.Replace(newFormat, "mmm", "Jan", -1)
-	newFormat = strings.Replace(newFormat, "mm", "01", -1)
-	newFormat = strings.Replace(newFormat, "m", "1", -1)
-	newFormat = strings.Replace(newFormat, "dd", "02", -1)
-	newFormat = strings.Replace(newFormat, "d", "2", -1)
-	newFormat = strings.Replace(newFormat, "hh24", "15", -1)
-	newFormat = strings.Replace(newFormat, "hh", "03 PM", -1)
-	newFormat = strings.Replace(newFormat, "h", "3 PM", -1)
-	newFormat = strings.Replace(newFormat, "nn", "04", -1)
-	newFormat = strings.Replace(newFormat, "n", "4", -1)
-	newFormat = strings.Replace(newFormat, "ss", "05", -1)
-	newFormat = strings.Replace(newFormat, "s", "5", -1)
-	newFormat = strings.Replace(newFormat, "ww", "Monday", -1)
-	newFormat = strings.Replace(newFormat, "w", "Mon", -1)
-
-	return newFormat
-}
-
 // DateTimeAsString formats time.Time variables as strings, considering the format directive
+// See DateTimeParse() for the format tokens
 func DateTimeAsString(dt time.Time, format string) string {
-	newFormat := golangDateTimeFormat(format)
-
-	return dt.Format(newFormat)
+	return dateTimeFormat(dt, format, dateTimeLocale(CatalogFallbackLanguage))
 }
 
-// NowAsString formats time.Now() as string, considering the format directive
+// NowAsString formats the current time as string, considering the format directive
+// The current time comes from the package clock. See ClockSet()
 func NowAsString(format string) string {
-	newFormat := golangDateTimeFormat(format)
-
-	return time.Now().Format(newFormat)
+	return packageHelpers().NowAsString(format)
 }
 
 // Today returns today's date at zero hours, minutes, seconds, etc.
 // It returns a time and a yyyy-mm-dd formated string
 func Today() (time.Time, string) {
-	newFormat := golangDateTimeFormat("yyyy-mm-dd")
-
-	t := time.Now()
-	y := t.Year()
-	m := t.Month()
-	d := t.Day()
-
-	return time.Date(y, m, d, 0, 0, 0, 0, time.Local), t.Format(newFormat)
+	return packageHelpers().Today()
 }
 
 // Todayf returns today's date at zero hours, minutes, seconds, etc.
 // It returns a time and a custom formated string
 func Todayf(format string) (time.Time, string) {
-	newFormat := golangDateTimeFormat(format)
-
-	t := time.Now()
-	y := t.Year()
-	m := t.Month()
-	d := t.Day()
-
-	return time.Date(y, m, d, 0, 0, 0, 0, time.Local), t.Format(newFormat)
+	return packageHelpers().Todayf(format)
 }
 
 // YMD returns today's date tokenized as year, month and day of month
 func YMD() (int, int, int) {
-	t := time.Now()
-
-	return t.Year(), int(t.Month()), t.Day()
+	return packageHelpers().YMD()
 }
 
-// StringAsDateTime formats time.Time variables as strings, considering the format directive
+// StringAsDateTime converts a date-time string using given format string and return it as time.Time
+// It returns a zero time when the string doesn't match the format. Use DateTimeParse() to know why.
 func StringAsDateTime(s string, format string) time.Time {
-	goFormat := golangDateTimeFormat(format)
-
-	t, _ := time.Parse(goFormat, s)
+	t, _ := DateTimeParse(s, format)
 
 	return t
 }
 
 // CheckDate validates a date using the given format
 func CheckDate(format, dateTime string) bool {
-	f := golangDateTimeFormat(format)
-
-	if f == "" {
-		return false
-	}
-
-	_, err := time.Parse(f, dateTime)
+	_, err := DateTimeParse(dateTime, format)
 
 	return err == nil
 }
@@ -132,73 +77,17 @@ func YMDasDate(yyyymmdd string) (time.Time, error) {
 }
 
 // ElapsedTime returns difference between two dates in years, months, days, hours, monutes and seconds
+// The order of the dates doesn't matter, and fractions of second are ignored, so from 00:00:00.5 to 00:00:01 is 1 second.
+// Use PeriodBetween() to get a Period, that's easier to handle and keeps the nanoseconds
 // Thanks to icza@https://stackoverflow.com/a/36531443/1301019
 func ElapsedTime(dtx, dty time.Time) (int, int, int, int, int, int) {
-	// If locations are different, convert one to make them the same
-	if dtx.Location() != dty.Location() {
-		dty = dty.In(dtx.Location())
-	}
-
-	// if the dates are equal,
-	if dtx.Equal(dty) {
-		return 0, 0, 0, 0, 0, 0
-	}
-
-	// For correct calculations, assure dtx is before or equal
-	if dtx.After(dty) {
-		dtx, dty = dty, dtx
-	}
-
-	y1, M1, d1 := dtx.Date()
-
-	y2, M2, d2 := dty.Date()
-
-	h1, m1, s1 := dtx.Clock()
-
-	h2, m2, s2 := dty.Clock()
-
-	year := y2 - y1
-	month := int(M2 - M1)
-	day := d2 - d1
-	hour := h2 - h1
-	min := m2 - m1
-	sec := s2 - s1
-
-	// Normalize negative values
-	if sec < 0 {
-		sec += 60
-		min--
-	}
-
-	if min < 0 {
-		min += 60
-		hour--
-	}
-
-	if hour < 0 {
-		hour += 24
-		day--
-	}
-
-	if day < 0 {
-		// days in month:
-		t := time.Date(y1, M1, 32, 0, 0, 0, 0, time.UTC)
-
-		day += 32 - t.Day()
-
-		month--
-	}
+	p := PeriodBetween(dtx.Add(-time.Duration(dtx.Nanosecond())), dty.Add(-time.Duration(dty.Nanosecond())))
 
-	if month < 0 {
-		month += 12
-
-		year--
-	}
-
-	return year, month, day, hour, min, sec
+	return p.Years, p.Months, p.Days, p.Hours, p.Minutes, p.Seconds
 }
 
 // ElapsedMonths returns the number of elapsed months between two given dates
+// Only the months component of ElapsedTime() is considered, so it goes up to 11
 func ElapsedMonths(from, to time.Time) int {
 	// To produce calculations, "to" must be greater than "from"
 	if to.Before(from) || (from.Year() == to.Year() && from.Month() == to.Month()) {
@@ -222,9 +111,9 @@ func ElapsedYears(from, to time.Time) int {
 	return years
 }
 
-// YearsAge returns the number of years past since a given date
+// YearsAge returns the number of years past since a given date, until the current time of the package clock
 func YearsAge(birthdate time.Time) int {
-	return ElapsedYears(birthdate, time.Now())
+	return packageHelpers().YearsAge(birthdate)
 }
 
 // MonthLastDay returns the last day of month, considering the year for cover february in leap years
@@ -279,27 +168,7 @@ const (
 // DateStrCheckAge checks a date string considering minimum and maximum age
 // The resulting code can be translated to text, according prefered idiom, with DateStrCheckErrMessage
 func DateStrCheckAge(date, format string, yearsAgeMin, yearsAgeMax int, acceptEmpty bool) DateStrCheck {
-	if date == "" {
-		if !acceptEmpty {
-			return DateStrCheckErrEmpty
-		}
-
-		return DateStrCheckOk
-	}
-
-	dt := StringAsDateTime(date, format)
-
-	if dt.IsZero() {
-		return DateStrCheckErrInvalid
-	}
-
-	yearsAge := YearsAge(dt)
-
-	if !Between(yearsAge, yearsAgeMin, yearsAgeMax) {
-		return DateStrCheckErrOutOfRange
-	}
-
-	return DateStrCheckOk
+	return packageHelpers().DateStrCheckAge(date, format, yearsAgeMin, yearsAgeMax, acceptEmpty)
 }
 
 // DateStrCheckRange checks a date string considering minimum and maximum date range
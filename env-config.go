@@ -0,0 +1,413 @@
+package handy
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct tags read by EnvLoad()
+// Example: `env:"DB_PORT" default:"5432" required:"true"`, or `prefix:"DB_"` on a nested struct
+const (
+	EnvTag         = "env"
+	EnvTagDefault  = "default"
+	EnvTagRequired = "required"
+	EnvTagSep      = "sep"
+	EnvTagPrefix   = "prefix"
+)
+
+// ErrEnvMissing and ErrEnvMalformed are wrapped by EnvError, to be tested with errors.Is()
+var (
+	ErrEnvMissing   = errors.New("handy: required environment variable isn't set")
+	ErrEnvMalformed = errors.New("handy: malformed environment variable")
+)
+
+// EnvError describes a variable that couldn't be loaded into a struct field
+type EnvError struct {
+	// Var is the environment variable name, with prefixes
+	Var string
+	// Field is the field path, like "DB.Port". It's empty for errors not related to a struct
+	Field string
+	// Err wraps ErrEnvMissing or ErrEnvMalformed
+	Err error
+}
+
+// Error returns the variable, the field and the reason
+func (e EnvError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf(`environment variable "%s": %v`, e.Var, e.Err)
+	}
+
+	return fmt.Sprintf(`environment variable "%s" (%s): %v`, e.Var, e.Field, e.Err)
+}
+
+// Unwrap returns the reason, making errors.Is() work with ErrEnvMissing and ErrEnvMalformed
+func (e EnvError) Unwrap() error {
+	return e.Err
+}
+
+// EnvErrors is the list of all the variables EnvLoad() or EnvCollector couldn't read
+type EnvErrors []EnvError
+
+// Error returns all the errors separated by semicolons
+func (e EnvErrors) Error() string {
+	a := make([]string, len(e))
+
+	for i, v := range e {
+		a[i] = v.Error()
+	}
+
+	return strings.Join(a, "; ")
+}
+
+// Vars returns the names of the variables with errors
+func (e EnvErrors) Vars() []string {
+	a := make([]string, len(e))
+
+	for i, v := range e {
+		a[i] = v.Var
+	}
+
+	return a
+}
+
+// EnvLoad fills a struct, given by pointer, from environment variables, according the tags of its fields
+// Tag "env" gives the variable name; fields without it are left untouched, except nested structs.
+// Tag "default" gives the value used when the variable isn't set or is empty.
+// Tag "required", when "true", makes an error when the variable isn't set and there's no default.
+// Tag "sep" separates slice items and map entries, "," by default. Map keys and values are separated by ":".
+// Tag "prefix", on a nested struct or pointer to struct, is added to the names of its variables.
+// Tag "secret", when "true", marks the field as sensitive, as Secret fields and names recognized by EnvIsSensitive() are.
+// Sensitive values may be references, like "file:/run/secrets/db_pass", resolved by EnvResolve(), and are never shown in errors.
+// Supported types: string, bool, all integer and float types, time.Duration, url.URL, types implementing
+// encoding.TextUnmarshaler, and pointers, slices and maps of them.
+// Differently from EnvCheckMany(), every variable is checked: the returned EnvErrors holds all the missing and malformed ones.
+// A plain error is returned when the struct itself can't be loaded, like when a field has an unsupported type.
+func EnvLoad(v interface{}) error {
+	return EnvLoadPrefix(v, "")
+}
+
+// EnvLoadPrefix works like EnvLoad(), adding the prefix to all variable names
+// Example: EnvLoadPrefix(&cfg, "BILLING_") reads BILLING_DB_PORT for a field tagged `env:"DB_PORT"`
+func EnvLoadPrefix(v interface{}, prefix string) error {
+	return packageEnvHelpers().LoadPrefix(v, prefix)
+}
+
+// Load fills a struct from the bound environment. See EnvLoad()
+func (h EnvHelpers) Load(v interface{}) error {
+	return h.LoadPrefix(v, "")
+}
+
+// LoadPrefix fills a struct from the bound environment, adding the prefix to all variable names. See EnvLoadPrefix()
+func (h EnvHelpers) LoadPrefix(v interface{}, prefix string) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("handy: EnvLoad() requires a non-nil pointer to struct")
+	}
+
+	var errs EnvErrors
+
+	if err := envLoadStruct(rv.Elem(), prefix, "", h.env.Lookup, &errs); err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+	urlType             = reflect.TypeOf(url.URL{})
+)
+
+func envLoadStruct(rv reflect.Value, prefix, path string, lookup func(key string) (string, bool), errs *EnvErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+
+		// Unexported fields are skipped
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		name := sf.Tag.Get(EnvTag)
+
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			if err := envLoadNested(fv, prefix+sf.Tag.Get(EnvTagPrefix), path+sf.Name+".", lookup, errs); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// unsupported types are reported even when the variable is unset, so the mistake doesn't depend on the environment
+		if err := envCheckType(sf.Type); err != nil {
+			return fmt.Errorf("handy: %v on field %s", err, path+sf.Name)
+		}
+
+		required := false
+
+		if r := sf.Tag.Get(EnvTagRequired); r != "" {
+			var err error
+
+			if required, err = strconv.ParseBool(r); err != nil {
+				return fmt.Errorf("handy: invalid required tag %q on field %s", r, path+sf.Name)
+			}
+		}
+
+		sep := ","
+
+		if s, ok := sf.Tag.Lookup(EnvTagSep); ok && s != "" {
+			sep = s
+		}
+
+		key := prefix + name
+		value, _ := lookup(key)
+
+		if value == "" {
+			value = sf.Tag.Get(EnvTagDefault)
+		}
+
+		if value == "" {
+			if required {
+				*errs = append(*errs, EnvError{Var: key, Field: path + sf.Name, Err: ErrEnvMissing})
+			}
+
+			continue
+		}
+
+		sensitive := envFieldIsSensitive(sf, key)
+
+		if sensitive {
+			var err error
+
+			if value, err = EnvResolve(value); err != nil {
+				*errs = append(*errs, EnvError{Var: key, Field: path + sf.Name, Err: err})
+
+				continue
+			}
+		}
+
+		if err := envSetValue(fv, value, sep); err != nil {
+			// parsing errors usually quote the value
+			if sensitive {
+				err = errors.New("value redacted")
+			}
+
+			*errs = append(*errs, EnvError{Var: key, Field: path + sf.Name, Err: fmt.Errorf("%w: %v", ErrEnvMalformed, err)})
+		}
+	}
+
+	return nil
+}
+
+// envLoadNested visits fields without env tag, when they are structs or pointers to structs
+// Nil pointers are allocated, so the nested struct can receive defaults
+func envLoadNested(fv reflect.Value, prefix, path string, lookup func(key string) (string, bool), errs *EnvErrors) error {
+	t := fv.Type()
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || envIsScalar(t) {
+		return nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(t))
+		}
+
+		fv = fv.Elem()
+	}
+
+	return envLoadStruct(fv, prefix, path, lookup, errs)
+}
+
+// envIsScalar tells if a struct type is loaded from a single variable, instead of having its fields visited
+func envIsScalar(t reflect.Type) bool {
+	return t == urlType || reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// envUnsupportedError means the field type can't be loaded, what is a programming error, not a configuration one
+type envUnsupportedError struct {
+	t reflect.Type
+}
+
+func (e envUnsupportedError) Error() string {
+	return fmt.Sprintf("unsupported type %s", e.t)
+}
+
+// envCheckType returns envUnsupportedError when envSetValue() can't parse values of the type
+func envCheckType(t reflect.Type) error {
+	if t.Kind() == reflect.Ptr {
+		return envCheckType(t.Elem())
+	}
+
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) || t == durationType || t == urlType {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Slice:
+		return envCheckType(t.Elem())
+	case reflect.Map:
+		if err := envCheckType(t.Key()); err != nil {
+			return err
+		}
+
+		return envCheckType(t.Elem())
+	}
+
+	return envUnsupportedError{t}
+}
+
+// envSetValue parses s into fv, according fv type. See envCheckType()
+func envSetValue(fv reflect.Value, s, sep string) error {
+	t := fv.Type()
+
+	if t.Kind() == reflect.Ptr {
+		x := reflect.New(t.Elem())
+
+		if err := envSetValue(x.Elem(), s, sep); err != nil {
+			return err
+		}
+
+		fv.Set(x)
+
+		return nil
+	}
+
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		x := reflect.New(t)
+
+		if err := x.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+			return err
+		}
+
+		fv.Set(x.Elem())
+
+		return nil
+	}
+
+	switch t {
+	case durationType:
+		d, err := time.ParseDuration(s)
+
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(int64(d))
+
+		return nil
+	case urlType:
+		u, err := url.Parse(s)
+
+		if err != nil {
+			return err
+		}
+
+		fv.Set(reflect.ValueOf(*u))
+
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		fv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(f)
+	case reflect.Slice:
+		items := strings.Split(s, sep)
+		a := reflect.MakeSlice(t, len(items), len(items))
+
+		for i, item := range items {
+			if err := envSetValue(a.Index(i), strings.TrimSpace(item), sep); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(a)
+	case reflect.Map:
+		m := reflect.MakeMap(t)
+
+		for _, item := range strings.Split(s, sep) {
+			kv := strings.SplitN(item, ":", 2)
+
+			if len(kv) != 2 {
+				return fmt.Errorf(`map entry %q should be written as "key:value"`, item)
+			}
+
+			k, v := reflect.New(t.Key()).Elem(), reflect.New(t.Elem()).Elem()
+
+			if err := envSetValue(k, strings.TrimSpace(kv[0]), sep); err != nil {
+				return err
+			}
+
+			if err := envSetValue(v, strings.TrimSpace(kv[1]), sep); err != nil {
+				return err
+			}
+
+			m.SetMapIndex(k, v)
+		}
+
+		fv.Set(m)
+	default:
+		return envUnsupportedError{t}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package handy
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type envTestDB struct {
+	Host string `env:"HOST" default:"localhost"`
+	Port uint16 `env:"PORT" default:"5432"`
+}
+
+type envTestConfig struct {
+	Name    string            `env:"NAME" required:"true"`
+	Debug   bool              `env:"DEBUG"`
+	Workers int8              `env:"WORKERS" default:"4"`
+	Ratio   float32           `env:"RATIO"`
+	Timeout time.Duration     `env:"TIMEOUT" default:"1m30s"`
+	Hosts   []string          `env:"HOSTS"`
+	Ports   []int             `env:"PORTS" sep:";"`
+	Weights map[string]int    `env:"WEIGHTS"`
+	Start   Date              `env:"START"`
+	Limit   *int              `env:"LIMIT"`
+	Labels  map[string]string `env:"LABELS" sep:"|"`
+	DB      envTestDB         `prefix:"DB_"`
+	Replica *envTestDB        `prefix:"REPLICA_"`
+	Ignored string
+}
+
+// envTestSet defines the variables, returning a function that removes them
+func envTestSet(t *testing.T, vars map[string]string) func() {
+	for k, v := range vars {
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return func() {
+		for k := range vars {
+			_ = os.Unsetenv(k)
+		}
+	}
+}
+
+func TestEnvLoad(t *testing.T) {
+	defer envTestSet(t, map[string]string{
+		"APP_NAME":         "billing",
+		"APP_DEBUG":        "true",
+		"APP_RATIO":        "0.5",
+		"APP_HOSTS":        "a.example.com, b.example.com",
+		"APP_PORTS":        "80;443",
+		"APP_WEIGHTS":      "a:1,b:2",
+		"APP_START":        "2019-06-01",
+		"APP_LIMIT":        "10",
+		"APP_LABELS":       "team:core|tier:1",
+		"APP_DB_HOST":      "db.example.com",
+		"APP_REPLICA_PORT": "5433",
+	})()
+
+	var cfg envTestConfig
+
+	if err := EnvLoadPrefix(&cfg, "APP_"); err != nil {
+		t.Fatalf("Test has failed!\n\tGot: %v", err)
+	}
+
+	tcs := []struct {
+		summary        string
+		got            interface{}
+		expectedOutput interface{}
+	}{
+		{"string", cfg.Name, "billing"},
+		{"bool", cfg.Debug, true},
+		{"default int8", cfg.Workers, int8(4)},
+		{"float32", cfg.Ratio, float32(0.5)},
+		{"default duration", cfg.Timeout, 90 * time.Second},
+		{"slice", cfg.Hosts, []string{"a.example.com", "b.example.com"}},
+		{"slice with separator", cfg.Ports, []int{80, 443}},
+		{"map", cfg.Weights, map[string]int{"a": 1, "b": 2}},
+		{"map with separator", cfg.Labels, map[string]string{"team": "core", "tier": "1"}},
+		{"text unmarshaler", cfg.Start, NewDate(2019, 6, 1)},
+		{"pointer", *cfg.Limit, 10},
+		{"nested", cfg.DB, envTestDB{"db.example.com", 5432}},
+		{"nested pointer", *cfg.Replica, envTestDB{"localhost", 5433}},
+		{"untagged", cfg.Ignored, ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", tc.expectedOutput, tc.got)
+			}
+		})
+	}
+}
+
+func TestEnvLoadURL(t *testing.T) {
+	defer envTestSet(t, map[string]string{"ENVTEST_URL": "https://api.example.com/v1"})()
+
+	var cfg struct {
+		Endpoint url.URL `env:"ENVTEST_URL"`
+	}
+
+	if err := EnvLoad(&cfg); err != nil || cfg.Endpoint.Host != "api.example.com" || cfg.Endpoint.Path != "/v1" {
+		t.Errorf("Test has failed!\n\tGot: %+v, %v", cfg.Endpoint, err)
+	}
+}
+
+func TestEnvLoadErrors(t *testing.T) {
+	defer envTestSet(t, map[string]string{
+		"BAD_WORKERS": "300",
+		"BAD_TIMEOUT": "soon",
+		"BAD_DB_PORT": "-1",
+		"BAD_WEIGHTS": "a=1",
+	})()
+
+	var cfg envTestConfig
+
+	err := EnvLoadPrefix(&cfg, "BAD_")
+
+	var errs EnvErrors
+
+	if !errors.As(err, &errs) {
+		t.Fatalf("Test has failed!\n\tExpected EnvErrors, got %v", err)
+	}
+
+	expected := []string{"BAD_NAME", "BAD_WORKERS", "BAD_TIMEOUT", "BAD_WEIGHTS", "BAD_DB_PORT"}
+
+	if !reflect.DeepEqual(errs.Vars(), expected) {
+		t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", expected, errs.Vars())
+	}
+
+	if !errors.Is(errs[0], ErrEnvMissing) || !errors.Is(errs[1], ErrEnvMalformed) {
+		t.Errorf("Test has failed!\n\tGot: %v", errs)
+	}
+
+	if errs[4].Field != "DB.Port" {
+		t.Errorf("Test has failed!\n\tExpected: DB.Port, \n\tGot: %s", errs[4].Field)
+	}
+
+	var unsupported struct {
+		C chan int `env:"BAD_WORKERS"`
+	}
+
+	if err := EnvLoad(&unsupported); err == nil || errors.As(err, &errs) {
+		t.Errorf("Test has failed!\n\tExpected a plain error, got %v", err)
+	}
+
+	var unsupportedUnset struct {
+		C map[string]chan int `env:"UNSET_WORKERS"`
+	}
+
+	if err := EnvLoad(&unsupportedUnset); err == nil || errors.As(err, &errs) {
+		t.Errorf("Test has failed!\n\tExpected a plain error even without the variable, got %v", err)
+	}
+
+	if err := EnvLoad(cfg); err == nil {
+		t.Errorf("Test has failed! EnvLoad() accepted a struct that isn't a pointer")
+	}
+}
@@ -0,0 +1,462 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrEnvSyntax is wrapped by EnvSyntaxError, to be tested with errors.Is()
+var ErrEnvSyntax = errors.New("handy: malformed dotenv file")
+
+// EnvSyntaxError tells where a dotenv file is malformed
+type EnvSyntaxError struct {
+	// File is the file name, empty when parsing from a reader
+	File string
+	// Line and Column are 1-based. Column counts characters, not bytes
+	Line   int
+	Column int
+	Msg    string
+}
+
+// Error returns the position and the reason, like ".env:3:7: expected "=" after DB_HOST"
+func (e EnvSyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// Unwrap returns ErrEnvSyntax
+func (e EnvSyntaxError) Unwrap() error {
+	return ErrEnvSyntax
+}
+
+// EnvParse reads variables in dotenv format, returning them without touching the process environment
+// Each line holds KEY=VALUE, optionally preceded by "export". Names begin with a letter, followed by letters, digits and underscores.
+// Lines beginning with # are comments, as anything after " #" in unquoted values.
+// Values may be unquoted, single quoted, taken literally, or double quoted, accepting the escapes \n, \r, \t, \", \\ and \$.
+// Quoted values may span several lines. ${VAR}, $VAR and ${VAR:-default} are replaced in unquoted and double quoted values,
+// looking first at the variables already read, then at the environment. See EnvSourceSet()
+// The first malformed line stops the parsing with an EnvSyntaxError.
+func EnvParse(r io.Reader) (map[string]string, error) {
+	return packageEnvHelpers().Parse(r)
+}
+
+// Parse reads variables in dotenv format, without changing the bound environment, that is only read by interpolation. See EnvParse()
+func (h EnvHelpers) Parse(r io.Reader) (map[string]string, error) {
+	content, err := ioutil.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]string{}
+
+	if err := envParse(string(content), "", vars, false, h.get); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+// EnvFiles returns the dotenv file names in the given directory, in precedence order: .env, .env.local and .env.<environment>
+// Example: EnvFiles("", "production") returns [".env", ".env.local", ".env.production"]
+func EnvFiles(dir, environment string) []string {
+	files := []string{filepath.Join(dir, ".env"), filepath.Join(dir, ".env.local")}
+
+	if environment != "" {
+		files = append(files, filepath.Join(dir, ".env."+environment))
+	}
+
+	return files
+}
+
+// EnvReadFiles parses the dotenv files, returning the variables without touching the process environment
+// Later files take precedence, and may refer to variables of the earlier ones. Files that don't exist are skipped.
+func EnvReadFiles(files ...string) (map[string]string, error) {
+	return packageEnvHelpers().ReadFiles(files...)
+}
+
+// ReadFiles parses the dotenv files, without changing the bound environment. See EnvReadFiles()
+func (h EnvHelpers) ReadFiles(files ...string) (map[string]string, error) {
+	contents, err := envReadContents(files)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.parseContents(files, contents)
+}
+
+// envReadContents reads the files, leaving nil for the ones that don't exist
+func envReadContents(files []string) ([][]byte, error) {
+	contents := make([][]byte, len(files))
+
+	for i, fileName := range files {
+		content, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		contents[i] = content
+	}
+
+	return contents, nil
+}
+
+// parseContents parses the contents read by envReadContents(), later files taking precedence
+func (h EnvHelpers) parseContents(files []string, contents [][]byte) (map[string]string, error) {
+	vars := map[string]string{}
+
+	for i, content := range contents {
+		if content == nil {
+			continue
+		}
+
+		if err := envParse(string(content), files[i], vars, false, h.get); err != nil {
+			return nil, err
+		}
+	}
+
+	return vars, nil
+}
+
+// EnvLoadFiles parses the dotenv files, as EnvReadFiles() does, and defines the variables in the process environment
+// overwriteValues when false, makes the engine skip env vars that are already defined and not empty
+// Nothing is defined when some file is malformed.
+func EnvLoadFiles(overwriteValues bool, files ...string) error {
+	return packageEnvHelpers().LoadFiles(overwriteValues, files...)
+}
+
+// LoadFiles parses the dotenv files and defines the variables in the bound environment only. See EnvLoadFiles()
+func (h EnvHelpers) LoadFiles(overwriteValues bool, files ...string) error {
+	vars, err := h.ReadFiles(files...)
+
+	if err != nil {
+		return err
+	}
+
+	return h.setAll(vars, overwriteValues)
+}
+
+func (h EnvHelpers) setAll(vars map[string]string, overwriteValues bool) error {
+	for key, value := range vars {
+		if h.get(key) != "" && !overwriteValues {
+			continue
+		}
+
+		if err := h.env.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// envParser reads dotenv content, keeping track of the position for error messages
+type envParser struct {
+	src  string
+	pos  int
+	file string
+	vars map[string]string
+	// env returns the value of variables not defined in the file, for interpolation
+	env func(key string) string
+}
+
+// envParse reads src into vars. When lenient, malformed lines are skipped instead of stopping the parsing
+func envParse(src, file string, vars map[string]string, lenient bool, env func(key string) string) error {
+	p := &envParser{src: strings.ReplaceAll(src, "\r\n", "\n"), file: file, vars: vars, env: env}
+
+	for !p.eof() {
+		err := p.statement()
+
+		if err == nil {
+			continue
+		}
+
+		if !lenient {
+			return err
+		}
+
+		p.skipLine()
+	}
+
+	return nil
+}
+
+func (p *envParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *envParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *envParser) skipBlanks() {
+	for p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\r' {
+		p.pos++
+	}
+}
+
+// skipLine moves to the beginning of the next line
+func (p *envParser) skipLine() {
+	for !p.eof() && p.src[p.pos] != '\n' {
+		p.pos++
+	}
+
+	p.pos++
+}
+
+func (p *envParser) errorAt(pos int, format string, args ...interface{}) error {
+	lineStart := strings.LastIndexByte(p.src[:pos], '\n') + 1
+
+	return EnvSyntaxError{
+		File:   p.file,
+		Line:   strings.Count(p.src[:pos], "\n") + 1,
+		Column: utf8.RuneCountInString(p.src[lineStart:pos]) + 1,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// statement reads a blank line, a comment or a KEY=VALUE assignment, up to the end of the line
+func (p *envParser) statement() error {
+	p.skipBlanks()
+
+	switch p.peek() {
+	case '\n', 0:
+		p.pos++
+		return nil
+	case '#':
+		p.skipLine()
+		return nil
+	}
+
+	if strings.HasPrefix(p.src[p.pos:], "export") && p.pos+6 < len(p.src) && (p.src[p.pos+6] == ' ' || p.src[p.pos+6] == '\t') {
+		p.pos += 6
+		p.skipBlanks()
+	}
+
+	start := p.pos
+
+	if c := p.peek(); !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z') {
+		return p.errorAt(p.pos, "variable names should begin with a letter")
+	}
+
+	for c := p.peek(); c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_'; c = p.peek() {
+		p.pos++
+	}
+
+	key := p.src[start:p.pos]
+
+	p.skipBlanks()
+
+	if p.peek() != '=' {
+		return p.errorAt(p.pos, `expected "=" after %s`, key)
+	}
+
+	p.pos++
+	p.skipBlanks()
+
+	var (
+		value string
+		err   error
+	)
+
+	switch p.peek() {
+	case '\'':
+		value, err = p.singleQuoted()
+	case '"':
+		value, err = p.doubleQuoted()
+	default:
+		value, err = p.unquoted()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.skipBlanks()
+
+	switch p.peek() {
+	case '#':
+		p.skipLine()
+	case '\n', 0:
+		p.pos++
+	default:
+		return p.errorAt(p.pos, "unexpected %q after the value of %s", p.peek(), key)
+	}
+
+	p.vars[key] = value
+
+	return nil
+}
+
+func (p *envParser) singleQuoted() (string, error) {
+	start := p.pos
+	end := strings.IndexByte(p.src[start+1:], '\'')
+
+	if end < 0 {
+		return "", p.errorAt(start, "unterminated single quoted value")
+	}
+
+	p.pos = start + 1 + end + 1
+
+	return p.src[start+1 : start+1+end], nil
+}
+
+func (p *envParser) doubleQuoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+
+	for {
+		if p.eof() {
+			return "", p.errorAt(start, "unterminated double quoted value")
+		}
+
+		c := p.src[p.pos]
+
+		switch c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if p.pos+1 >= len(p.src) {
+				return "", p.errorAt(start, "unterminated double quoted value")
+			}
+
+			switch e := p.src[p.pos+1]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(e)
+			default:
+				return "", p.errorAt(p.pos, `unknown escape sequence "\%c"`, e)
+			}
+
+			p.pos += 2
+		case '$':
+			if err := p.interpolate(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// unquoted reads up to the end of the line, or up to a comment, trimming trailing blanks
+func (p *envParser) unquoted() (string, error) {
+	var b strings.Builder
+
+	for !p.eof() {
+		c := p.src[p.pos]
+
+		if c == '\n' || c == '#' && p.pos > 0 && (p.src[p.pos-1] == ' ' || p.src[p.pos-1] == '\t') {
+			break
+		}
+
+		if c == '$' {
+			if err := p.interpolate(&b); err != nil {
+				return "", err
+			}
+
+			continue
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	return strings.TrimRight(b.String(), " \t\r"), nil
+}
+
+// interpolate replaces ${VAR}, ${VAR:-default} or $VAR, starting at the dollar sign
+// A dollar sign not followed by a name is kept as is.
+func (p *envParser) interpolate(b *strings.Builder) error {
+	start := p.pos
+	p.pos++
+
+	braces := p.peek() == '{'
+
+	if braces {
+		p.pos++
+	}
+
+	nameStart := p.pos
+
+	for c := p.peek(); c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_'; c = p.peek() {
+		p.pos++
+	}
+
+	name := p.src[nameStart:p.pos]
+
+	if !braces {
+		if name == "" {
+			b.WriteByte('$')
+
+			return nil
+		}
+
+		b.WriteString(p.lookup(name))
+
+		return nil
+	}
+
+	fallback := ""
+
+	if strings.HasPrefix(p.src[p.pos:], ":-") {
+		end := strings.IndexAny(p.src[p.pos:], "}\n")
+
+		if end < 0 || p.src[p.pos+end] != '}' {
+			return p.errorAt(start, `unterminated "${"`)
+		}
+
+		fallback = p.src[p.pos+2 : p.pos+end]
+		p.pos += end
+	}
+
+	if name == "" || p.peek() != '}' {
+		return p.errorAt(start, `malformed "${...}" reference`)
+	}
+
+	p.pos++
+
+	if v := p.lookup(name); v != "" {
+		b.WriteString(v)
+	} else {
+		b.WriteString(fallback)
+	}
+
+	return nil
+}
+
+func (p *envParser) lookup(name string) string {
+	if v, ok := p.vars[name]; ok {
+		return v
+	}
+
+	return p.env(name)
+}
@@ -0,0 +1,138 @@
+package handy
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnvParse(t *testing.T) {
+	defer envTestSet(t, map[string]string{"DOTENV_TEST_HOME": "/home/app"})()
+
+	content := strings.Join([]string{
+		"# comment",
+		"",
+		"PLAIN=hello world",
+		"export EXPORTED = yes",
+		"EMPTY=",
+		"INLINE=value # comment",
+		"HASH=a#b",
+		"SINGLE='literal $PLAIN \\n' # comment",
+		`DOUBLE="tab\there \"quoted\" \$5"`,
+		"MULTI=\"first",
+		"second\"",
+		"RAW='line 1",
+		"line 2'",
+		"REF=${PLAIN}!",
+		"BARE=$DOTENV_TEST_HOME/data",
+		"FALLBACK=${DOTENV_TEST_MISSING:-none}",
+		"DOLLAR=5$",
+		"WINDOWS=crlf\r",
+	}, "\n")
+
+	vars, err := EnvParse(strings.NewReader(content))
+
+	if err != nil {
+		t.Fatalf("Test has failed!\n\tGot: %v", err)
+	}
+
+	expected := map[string]string{
+		"PLAIN":    "hello world",
+		"EXPORTED": "yes",
+		"EMPTY":    "",
+		"INLINE":   "value",
+		"HASH":     "a#b",
+		"SINGLE":   "literal $PLAIN \\n",
+		"DOUBLE":   "tab\there \"quoted\" $5",
+		"MULTI":    "first\nsecond",
+		"RAW":      "line 1\nline 2",
+		"REF":      "hello world!",
+		"BARE":     "/home/app/data",
+		"FALLBACK": "none",
+		"DOLLAR":   "5$",
+		"WINDOWS":  "crlf",
+	}
+
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("Test has failed!\n\tExpected: %q, \n\tGot: %q", expected, vars)
+	}
+
+	if os.Getenv("PLAIN") != "" {
+		t.Errorf("Test has failed! EnvParse() changed the process environment")
+	}
+}
+
+func TestEnvParseErrors(t *testing.T) {
+	tcs := []struct {
+		content        string
+		expectedOutput string
+	}{
+		{"A=1\nINVALID LINE", `2:9: expected "=" after INVALID`},
+		{"_A=1", "1:1: variable names should begin with a letter"},
+		{"A=1\n  B='open", "2:5: unterminated single quoted value"},
+		{"A=\"open\nstill open", "1:3: unterminated double quoted value"},
+		{`A="\q"`, `1:4: unknown escape sequence "\q"`},
+		{"A='x' y", `1:7: unexpected 'y' after the value of A`},
+		{"A=${B", `1:3: malformed "${...}" reference`},
+		{"ÁÉ=1", "1:1: variable names should begin with a letter"},
+		{"A=1\nÇ=x B", "2:1: variable names should begin with a letter"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.expectedOutput, func(t *testing.T) {
+			_, err := EnvParse(strings.NewReader(tc.content))
+
+			if !errors.Is(err, ErrEnvSyntax) || err.Error() != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %v", tc.expectedOutput, err)
+			}
+		})
+	}
+}
+
+func TestEnvReadFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "handy-dotenv")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".env":            "DB_HOST=localhost\nDB_PORT=5432\nDB_URL=postgres://${DB_HOST}:${DB_PORT}",
+		".env.local":      "DB_PORT=6543",
+		".env.production": "DB_HOST=db.example.com\nDB_URL=postgres://${DB_HOST}:${DB_PORT}",
+	}
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	vars, err := EnvReadFiles(EnvFiles(dir, "production")...)
+
+	expected := map[string]string{"DB_HOST": "db.example.com", "DB_PORT": "6543", "DB_URL": "postgres://db.example.com:6543"}
+
+	if err != nil || !reflect.DeepEqual(vars, expected) {
+		t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v, %v", expected, vars, err)
+	}
+
+	if _, err := EnvReadFiles(EnvFiles(dir, "staging")...); err != nil {
+		t.Errorf("Test has failed! A missing file should be skipped, got %v", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, ".env.broken"), []byte("OK=1\nBROKEN"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var syntaxErr EnvSyntaxError
+
+	if _, err := EnvReadFiles(EnvFiles(dir, "broken")...); !errors.As(err, &syntaxErr) || syntaxErr.Line != 2 || !strings.HasSuffix(syntaxErr.File, ".env.broken") {
+		t.Errorf("Test has failed!\n\tExpected an error at line 2 of .env.broken, got %v", err)
+	}
+}
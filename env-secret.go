@@ -0,0 +1,263 @@
+package handy
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// EnvMask replaces sensitive values in debug output, errors and printed configurations
+const EnvMask = "******"
+
+// EnvTagSecret marks a struct field as sensitive: `env:"DB_PASS" secret:"true"`
+const EnvTagSecret = "secret"
+
+// ErrEnvReference is wrapped by the errors of EnvResolve(), to be tested with errors.Is()
+var ErrEnvReference = errors.New("handy: unresolvable secret reference")
+
+// Secret is a string that doesn't show itself: String(), GoString() and MarshalText() return EnvMask
+// So a Secret field can't leak by accident through fmt, log or encoding/json. Use Reveal() to get the value
+type Secret string
+
+// Reveal returns the secret value
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns EnvMask, or "" for an empty secret
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return EnvMask
+}
+
+// GoString returns EnvMask quoted, so %#v doesn't show the value
+func (s Secret) GoString() string {
+	return `"` + s.String() + `"`
+}
+
+// MarshalText returns EnvMask, or "" for an empty secret
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// envSensitivePatterns are parts of variable names that make them sensitive by default
+// PASS covers PASSWORD and PASSWD too, and DSN covers connection strings, that usually carry a password
+var envSensitivePatterns = []string{"PASS", "SECRET", "TOKEN", "PRIVATE_KEY", "API_KEY", "ACCESS_KEY", "CREDENTIAL", "DSN"}
+
+var envSensitive = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: map[string]bool{}}
+
+// EnvSensitiveRegister marks variables as sensitive, besides the ones recognized by name. See EnvIsSensitive()
+func EnvSensitiveRegister(names ...string) {
+	envSensitive.Lock()
+	defer envSensitive.Unlock()
+
+	for _, name := range names {
+		envSensitive.names[name] = true
+	}
+}
+
+// EnvIsSensitive returns true for variables registered with EnvSensitiveRegister(),
+// and for names containing PASS, SECRET, TOKEN, PRIVATE_KEY, API_KEY, ACCESS_KEY, CREDENTIAL or DSN, in any case
+// Sensitive values are masked in debug output and errors, and may be references resolved by EnvResolve()
+func EnvIsSensitive(name string) bool {
+	envSensitive.RLock()
+	registered := envSensitive.names[name]
+	envSensitive.RUnlock()
+
+	if registered {
+		return true
+	}
+
+	upper := strings.ToUpper(name)
+
+	for _, p := range envSensitivePatterns {
+		if strings.Contains(upper, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// envMaskURLPassword masks the password of a URL value, like "postgres://app:s3cr3t@db/app", returning other values as they are
+func envMaskURLPassword(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+
+	u, err := url.Parse(value)
+
+	if err != nil || u.User == nil {
+		return value
+	}
+
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return value
+	}
+
+	u.User = url.UserPassword(u.User.Username(), EnvMask)
+
+	return u.String()
+}
+
+// EnvResolve returns the value a secret reference points to:
+// "file:/run/secrets/db_pass" returns the file content, without the trailing line break, as written by docker and kubernetes;
+// "base64:c2VjcmV0" returns the decoded value.
+// Other values are returned as they are.
+func EnvResolve(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "file:"):
+		b, err := ioutil.ReadFile(strings.TrimPrefix(value, "file:"))
+
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrEnvReference, err)
+		}
+
+		return strings.TrimRight(string(b), "\r\n"), nil
+	case strings.HasPrefix(value, "base64:"):
+		b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, "base64:"))
+
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid base64", ErrEnvReference)
+		}
+
+		return string(b), nil
+	}
+
+	return value, nil
+}
+
+// EnvSecret returns the env var value as Secret, resolving references like "file:" and "base64:". See EnvResolve()
+func EnvSecret(key string) (Secret, error) {
+	return packageEnvHelpers().Secret(key)
+}
+
+// Secret returns the env var value as Secret, resolving references. See EnvSecret()
+func (h EnvHelpers) Secret(key string) (Secret, error) {
+	s, err := EnvResolve(h.get(key))
+
+	if err != nil {
+		return "", EnvError{Var: key, Err: err}
+	}
+
+	return Secret(s), nil
+}
+
+// Secret returns the env var value as Secret, keeping the error when the reference can't be resolved. See EnvSecret()
+func (c *EnvCollector) Secret(key string) Secret {
+	s, err := c.helpers().Secret(key)
+
+	c.add(err)
+
+	return s
+}
+
+// EnvPrint writes the effective configuration loaded by EnvLoadPrefix(), one VAR=value per line, in fields order
+// Sensitive values, that are Secret fields, fields tagged `secret:"true"` and variables recognized by EnvIsSensitive(), are masked,
+// as are the passwords of URLs, like DATABASE_URL=postgres://app:******@db/app.
+// Example: handy.EnvPrint(os.Stderr, &cfg, "")
+func EnvPrint(w io.Writer, v interface{}, prefix string) error {
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("handy: EnvPrint() requires a non-nil struct")
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("handy: EnvPrint() requires a struct, got %s", rv.Kind())
+	}
+
+	return envPrintStruct(w, rv, prefix)
+}
+
+func envPrintStruct(w io.Writer, rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		name := sf.Tag.Get(EnvTag)
+
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+
+			if fv.Kind() == reflect.Struct && !envIsScalar(fv.Type()) {
+				if err := envPrintStruct(w, fv, prefix+sf.Tag.Get(EnvTagPrefix)); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		key := prefix + name
+		value := envFormatValue(fv)
+
+		if value != "" && envFieldIsSensitive(sf, key) {
+			value = EnvMask
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// envFieldIsSensitive tells if a struct field holds a sensitive value
+func envFieldIsSensitive(sf reflect.StructField, key string) bool {
+	t := sf.Type
+
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t == secretType || sf.Tag.Get(EnvTagSecret) == "true" || EnvIsSensitive(key)
+}
+
+// envFormatValue writes a field value, with nil pointers as empty and URL passwords masked, even in string fields
+func envFormatValue(fv reflect.Value) string {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return ""
+		}
+
+		fv = fv.Elem()
+	}
+
+	if u, ok := fv.Interface().(url.URL); ok {
+		return envMaskURLPassword(u.String())
+	}
+
+	return envMaskURLPassword(fmt.Sprint(fv.Interface()))
+}
@@ -0,0 +1,328 @@
+package handy
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Env is a source of environment variables. The Env* functions read the package source, OSEnv by default,
+// and WithEnv() binds the same helpers to any other source, so tests can run isolated and in parallel
+type Env interface {
+	// Lookup returns the variable value, and false when it isn't defined
+	Lookup(key string) (string, bool)
+	Set(key, value string) error
+	Unset(key string) error
+	// Environ returns a copy of all the variables
+	Environ() map[string]string
+}
+
+// OSEnv is the process environment, backed by os.LookupEnv() and os.Setenv()
+type OSEnv struct{}
+
+// Lookup calls os.LookupEnv()
+func (OSEnv) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Set calls os.Setenv()
+func (OSEnv) Set(key, value string) error {
+	return os.Setenv(key, value)
+}
+
+// Unset calls os.Unsetenv()
+func (OSEnv) Unset(key string) error {
+	return os.Unsetenv(key)
+}
+
+// Environ returns os.Environ() as a map
+func (OSEnv) Environ() map[string]string {
+	m := map[string]string{}
+
+	for _, kv := range os.Environ() {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			m[kv[:i]] = kv[i+1:]
+		}
+	}
+
+	return m
+}
+
+// MapEnv is an isolated environment kept in memory. It's safe for concurrent use
+type MapEnv struct {
+	mu   sync.RWMutex
+	vars map[string]string
+}
+
+// NewMapEnv returns an environment holding a copy of the given variables, that may be nil
+func NewMapEnv(vars map[string]string) *MapEnv {
+	e := &MapEnv{vars: make(map[string]string, len(vars))}
+
+	for k, v := range vars {
+		e.vars[k] = v
+	}
+
+	return e
+}
+
+// Lookup returns the variable value, and false when it isn't defined
+func (e *MapEnv) Lookup(key string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	v, ok := e.vars[key]
+
+	return v, ok
+}
+
+// Set defines the variable
+func (e *MapEnv) Set(key, value string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.vars[key] = value
+
+	return nil
+}
+
+// Unset removes the variable
+func (e *MapEnv) Unset(key string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.vars, key)
+
+	return nil
+}
+
+// Environ returns a copy of all the variables
+func (e *MapEnv) Environ() map[string]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	m := make(map[string]string, len(e.vars))
+
+	for k, v := range e.vars {
+		m[k] = v
+	}
+
+	return m
+}
+
+// LayeredEnv reads variables from several sources, the first one defining a variable winning
+// Changes are kept by the LayeredEnv itself, and never reach the layers: Unset() hides a variable, Set() shadows it.
+// So NewLayeredEnv(NewMapEnv(overrides), OSEnv{}) sees the process environment, without being able to change it.
+// It's safe for concurrent use, as long as its layers are.
+type LayeredEnv struct {
+	mu      sync.RWMutex
+	changed map[string]string
+	removed map[string]bool
+	layers  []Env
+}
+
+// NewLayeredEnv returns an environment reading the layers in order
+func NewLayeredEnv(layers ...Env) *LayeredEnv {
+	return &LayeredEnv{changed: map[string]string{}, removed: map[string]bool{}, layers: layers}
+}
+
+// Lookup returns the variable value from the changes, or from the first layer defining it
+func (e *LayeredEnv) Lookup(key string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if v, ok := e.changed[key]; ok {
+		return v, true
+	}
+
+	if e.removed[key] {
+		return "", false
+	}
+
+	for _, l := range e.layers {
+		if v, ok := l.Lookup(key); ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Set defines the variable, without touching the layers
+func (e *LayeredEnv) Set(key, value string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.changed[key] = value
+	delete(e.removed, key)
+
+	return nil
+}
+
+// Unset hides the variable, without touching the layers
+func (e *LayeredEnv) Unset(key string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.changed, key)
+	e.removed[key] = true
+
+	return nil
+}
+
+// Environ returns the variables visible through the layers and the changes
+func (e *LayeredEnv) Environ() map[string]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	m := map[string]string{}
+
+	for i := len(e.layers) - 1; i >= 0; i-- {
+		for k, v := range e.layers[i].Environ() {
+			m[k] = v
+		}
+	}
+
+	for k := range e.removed {
+		delete(m, k)
+	}
+
+	for k, v := range e.changed {
+		m[k] = v
+	}
+
+	return m
+}
+
+// EnvSnapshot holds the variables of an environment at a given moment, to restore them later
+type EnvSnapshot struct {
+	env  Env
+	vars map[string]string
+}
+
+// EnvTakeSnapshot copies the variables of the environment
+// Typical use in tests: defer handy.EnvTakeSnapshot(handy.OSEnv{}).Restore()
+func EnvTakeSnapshot(e Env) *EnvSnapshot {
+	return &EnvSnapshot{env: e, vars: e.Environ()}
+}
+
+// Vars returns a copy of the variables in the snapshot
+func (s *EnvSnapshot) Vars() map[string]string {
+	m := make(map[string]string, len(s.vars))
+
+	for k, v := range s.vars {
+		m[k] = v
+	}
+
+	return m
+}
+
+// Restore brings the environment back to the snapshot, removing variables defined after it
+func (s *EnvSnapshot) Restore() error {
+	current := s.env.Environ()
+
+	keys := make([]string, 0, len(current))
+
+	for k := range current {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, ok := s.vars[k]; !ok {
+			if err := s.env.Unset(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	for k, v := range s.vars {
+		if cv, ok := current[k]; ok && cv == v {
+			continue
+		}
+
+		if err := s.env.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var packageEnv = struct {
+	sync.RWMutex
+	env Env
+}{env: OSEnv{}}
+
+// EnvSourceSet replaces the environment read by the package-level Env* functions, returning a function that restores the previous one
+// As the source is shared by the whole package, tests running in parallel should prefer WithEnv()
+func EnvSourceSet(e Env) (restore func()) {
+	if e == nil {
+		e = OSEnv{}
+	}
+
+	packageEnv.Lock()
+	previous := packageEnv.env
+	packageEnv.env = e
+	packageEnv.Unlock()
+
+	return func() {
+		packageEnv.Lock()
+		packageEnv.env = previous
+		packageEnv.Unlock()
+	}
+}
+
+// EnvSource returns the environment read by the package-level Env* functions
+func EnvSource() Env {
+	packageEnv.RLock()
+	defer packageEnv.RUnlock()
+
+	return packageEnv.env
+}
+
+// EnvOverride returns the env helpers reading the given variables over the package source, which is left untouched
+// Changes made through the helpers, like by Check() or LoadFromDisk(), stay in the override, and never reach the source.
+// As nothing is shared, tests using it may run in parallel.
+// Example: cfg := handy.EnvOverride(map[string]string{"PORT": "8080"}).Int("PORT", 80)
+func EnvOverride(vars map[string]string) EnvHelpers {
+	return packageEnvHelpers().Override(vars)
+}
+
+// EnvHelpers gives the env getters and loaders, bound to a given environment
+type EnvHelpers struct {
+	env Env
+}
+
+// WithEnv returns the env helpers bound to the given environment, without touching the package source
+// Example: handy.WithEnv(handy.NewMapEnv(map[string]string{"PORT": "8080"})).Int("PORT", 80)
+func WithEnv(e Env) EnvHelpers {
+	if e == nil {
+		e = OSEnv{}
+	}
+
+	return EnvHelpers{env: e}
+}
+
+// packageEnvHelpers returns the helpers bound to the package source
+func packageEnvHelpers() EnvHelpers {
+	return EnvHelpers{env: EnvSource()}
+}
+
+// Env returns the environment the helpers are bound to
+func (h EnvHelpers) Env() Env {
+	return h.env
+}
+
+// Override returns the helpers reading the given variables over the environment of h, which is left untouched. See EnvOverride()
+func (h EnvHelpers) Override(vars map[string]string) EnvHelpers {
+	return WithEnv(NewLayeredEnv(NewMapEnv(vars), h.env))
+}
+
+// get returns the variable value, or "" when it isn't defined
+func (h EnvHelpers) get(key string) string {
+	v, _ := h.env.Lookup(key)
+
+	return v
+}
@@ -0,0 +1,161 @@
+package handy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvIsolation(t *testing.T) {
+	for i := 0; i < 4; i++ {
+		i := i
+
+		t.Run(fmt.Sprintf("parallel %d", i), func(t *testing.T) {
+			t.Parallel()
+
+			h := WithEnv(NewLayeredEnv(NewMapEnv(map[string]string{"ISOLATED_PORT": fmt.Sprint(8080 + i)}), OSEnv{}))
+
+			if port := h.Int("ISOLATED_PORT", 80); port != 8080+i {
+				t.Errorf("Test has failed!\n\tExpected: %d, \n\tGot: %d", 8080+i, port)
+			}
+
+			if err := h.Check("ISOLATED_NAME", "worker", true, false); err != nil || h.Str("ISOLATED_NAME", "") != "worker" {
+				t.Errorf("Test has failed!\n\tGot: %v, %q", err, h.Str("ISOLATED_NAME", ""))
+			}
+
+			if _, ok := os.LookupEnv("ISOLATED_NAME"); ok {
+				t.Errorf("Test has failed! EnvCheck() leaked into the process environment")
+			}
+		})
+	}
+}
+
+func TestLayeredEnv(t *testing.T) {
+	base := NewMapEnv(map[string]string{"A": "base", "B": "base"})
+	e := NewLayeredEnv(NewMapEnv(map[string]string{"A": "top"}), base)
+
+	if v, _ := e.Lookup("A"); v != "top" {
+		t.Errorf("Test has failed!\n\tExpected: top, \n\tGot: %s", v)
+	}
+
+	_ = e.Set("C", "new")
+	_ = e.Unset("B")
+
+	if _, ok := e.Lookup("B"); ok {
+		t.Errorf("Test has failed! B should be hidden")
+	}
+
+	if v, _ := base.Lookup("B"); v != "base" {
+		t.Errorf("Test has failed! Unset() reached the layer")
+	}
+
+	if _, ok := base.Lookup("C"); ok {
+		t.Errorf("Test has failed! Set() reached the layer")
+	}
+
+	if m := e.Environ(); len(m) != 2 || m["A"] != "top" || m["C"] != "new" {
+		t.Errorf("Test has failed!\n\tGot: %v", m)
+	}
+}
+
+func TestEnvSnapshot(t *testing.T) {
+	defer envTestSet(t, map[string]string{"SNAPSHOT_KEEP": "1"})()
+
+	s := EnvTakeSnapshot(OSEnv{})
+
+	_ = os.Setenv("SNAPSHOT_KEEP", "2")
+	_ = os.Setenv("SNAPSHOT_NEW", "x")
+
+	if err := s.Restore(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := os.Getenv("SNAPSHOT_KEEP"); v != "1" {
+		t.Errorf("Test has failed!\n\tExpected: 1, \n\tGot: %s", v)
+	}
+
+	if _, ok := os.LookupEnv("SNAPSHOT_NEW"); ok {
+		t.Errorf("Test has failed! SNAPSHOT_NEW should be removed")
+	}
+
+	if s.Vars()["SNAPSHOT_KEEP"] != "1" {
+		t.Errorf("Test has failed!\n\tGot: %v", s.Vars()["SNAPSHOT_KEEP"])
+	}
+}
+
+func TestEnvOverride(t *testing.T) {
+	for _, port := range []int{9090, 9091} {
+		port := port
+
+		t.Run(fmt.Sprint(port), func(t *testing.T) {
+			t.Parallel()
+
+			h := EnvOverride(map[string]string{"OVERRIDE_PORT": fmt.Sprint(port)})
+
+			if tr := h.Int("OVERRIDE_PORT", 80); tr != port {
+				t.Errorf("Test has failed!\n\tExpected: %d, \n\tGot: %d", port, tr)
+			}
+
+			if err := h.Check("OVERRIDE_NAME", "temporary", false, false); err != nil {
+				t.Fatal(err)
+			}
+
+			if h.Str("OVERRIDE_NAME", "") != "temporary" || h.Override(nil).Str("OVERRIDE_NAME", "") != "temporary" {
+				t.Errorf("Test has failed! The override should keep its changes")
+			}
+
+			if _, ok := os.LookupEnv("OVERRIDE_NAME"); ok || EnvStr("OVERRIDE_PORT", "") != "" {
+				t.Errorf("Test has failed! The override leaked out of its scope")
+			}
+		})
+	}
+}
+
+func TestEnvLoadFilesIsolated(t *testing.T) {
+	dir, err := ioutil.TempDir("", "handy-env-source")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, ".env")
+
+	if err := ioutil.WriteFile(fileName, []byte("ISOLATED_DSN=postgres://localhost\nISOLATED_PORT=5432"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewMapEnv(nil)
+	h := WithEnv(e)
+
+	if err := h.LoadFiles(true, fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.LoadFromDisk(fileName, true, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg struct {
+		DSN  string `env:"ISOLATED_DSN" required:"true"`
+		Port int    `env:"ISOLATED_PORT"`
+	}
+
+	if err := h.Load(&cfg); err != nil || cfg.DSN != "postgres://localhost" || cfg.Port != 5432 {
+		t.Errorf("Test has failed!\n\tGot: %+v, %v", cfg, err)
+	}
+
+	if _, ok := os.LookupEnv("ISOLATED_DSN"); ok {
+		t.Errorf("Test has failed! Loading into an isolated Env leaked into the process environment")
+	}
+
+	c := h.Collector()
+	c.Required("ISOLATED_DSN")
+
+	if err := c.Err(); err != nil {
+		t.Errorf("Test has failed!\n\tExpected nil, got %v", err)
+	}
+}
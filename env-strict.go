@@ -0,0 +1,250 @@
+package handy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// envMalformed returns the error reported by the strict getters for a value that can't be parsed
+func envMalformed(key string, err error) error {
+	return EnvError{Var: key, Err: fmt.Errorf("%w: %v", ErrEnvMalformed, err)}
+}
+
+// EnvIntE returns the env var value as int, or the default value when it isn't set
+// A malformed value returns the default value and an EnvError wrapping ErrEnvMalformed
+func EnvIntE(key string, defaultValue int) (int, error) {
+	return packageEnvHelpers().IntE(key, defaultValue)
+}
+
+// IntE returns the env var value as int, with an error when it's malformed. See EnvIntE()
+func (h EnvHelpers) IntE(key string, defaultValue int) (int, error) {
+	s := h.get(key)
+
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+
+	if err != nil {
+		return defaultValue, envMalformed(key, err)
+	}
+
+	return i, nil
+}
+
+// EnvInt64E returns the env var value as int64, or the default value when it isn't set
+// A malformed value returns the default value and an EnvError wrapping ErrEnvMalformed
+func EnvInt64E(key string, defaultValue int64) (int64, error) {
+	return packageEnvHelpers().Int64E(key, defaultValue)
+}
+
+// Int64E returns the env var value as int64, with an error when it's malformed. See EnvInt64E()
+func (h EnvHelpers) Int64E(key string, defaultValue int64) (int64, error) {
+	s := h.get(key)
+
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+
+	if err != nil {
+		return defaultValue, envMalformed(key, err)
+	}
+
+	return i, nil
+}
+
+// EnvIntSE returns the env var value as []int, or the default value when it isn't set
+// Spaces around the items are ignored, so "1, 2, 3" is accepted.
+// When any item is malformed, like "x" in "1,x,3", it returns the default value and an EnvError wrapping ErrEnvMalformed
+func EnvIntSE(key, separator string, defaultValue []int) ([]int, error) {
+	return packageEnvHelpers().IntSE(key, separator, defaultValue)
+}
+
+// IntSE returns the env var value as []int, with an error when it's malformed. See EnvIntSE()
+func (h EnvHelpers) IntSE(key, separator string, defaultValue []int) ([]int, error) {
+	if defaultValue == nil {
+		defaultValue = []int{}
+	}
+
+	s := h.get(key)
+
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	a := strings.Split(s, separator)
+
+	is := make([]int, len(a))
+
+	for i, x := range a {
+		var err error
+
+		if is[i], err = strconv.Atoi(strings.TrimSpace(x)); err != nil {
+			return defaultValue, envMalformed(key, fmt.Errorf("item %d: %v", i+1, err))
+		}
+	}
+
+	return is, nil
+}
+
+// EnvFloat64E returns the env var value as float64, or the default value when it isn't set
+// A malformed value returns the default value and an EnvError wrapping ErrEnvMalformed
+func EnvFloat64E(key string, defaultValue float64) (float64, error) {
+	return packageEnvHelpers().Float64E(key, defaultValue)
+}
+
+// Float64E returns the env var value as float64, with an error when it's malformed. See EnvFloat64E()
+func (h EnvHelpers) Float64E(key string, defaultValue float64) (float64, error) {
+	s := h.get(key)
+
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+
+	if err != nil {
+		return defaultValue, envMalformed(key, err)
+	}
+
+	return f, nil
+}
+
+// EnvBoolE returns the env var value as boolean, or the default value when it isn't set
+// Accepted values are the ones of strconv.ParseBool(): 1, t, T, TRUE, true, True, 0, f, F, FALSE, false and False
+// A malformed value returns the default value and an EnvError wrapping ErrEnvMalformed
+func EnvBoolE(key string, defaultValue bool) (bool, error) {
+	return packageEnvHelpers().BoolE(key, defaultValue)
+}
+
+// BoolE returns the env var value as boolean, with an error when it's malformed. See EnvBoolE()
+func (h EnvHelpers) BoolE(key string, defaultValue bool) (bool, error) {
+	s := h.get(key)
+
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+
+	if err != nil {
+		return defaultValue, envMalformed(key, err)
+	}
+
+	return b, nil
+}
+
+// EnvCollector reads many variables, keeping every error, so a service can fail once with a full report
+// The zero value reads the package source. See EnvHelpers.Collector() for other environments.
+// Example:
+// var c handy.EnvCollector
+// port := c.Int("PORT", 8080)
+// dsn := c.Required("DATABASE_URL")
+// if err := c.Err(); err != nil { log.Fatal(err) }
+type EnvCollector struct {
+	env  Env
+	errs EnvErrors
+}
+
+// Collector returns an EnvCollector reading the bound environment
+func (h EnvHelpers) Collector() *EnvCollector {
+	return &EnvCollector{env: h.env}
+}
+
+func (c *EnvCollector) helpers() EnvHelpers {
+	if c.env == nil {
+		return packageEnvHelpers()
+	}
+
+	return EnvHelpers{env: c.env}
+}
+
+func (c *EnvCollector) add(err error) {
+	if e, ok := err.(EnvError); ok {
+		c.errs = append(c.errs, e)
+	}
+}
+
+// Required returns the env var value as string, keeping an error wrapping ErrEnvMissing when it isn't set
+func (c *EnvCollector) Required(key string) string {
+	s := c.helpers().get(key)
+
+	if s == "" {
+		c.errs = append(c.errs, EnvError{Var: key, Err: ErrEnvMissing})
+	}
+
+	return s
+}
+
+// Str returns the env var value as string. See EnvStr()
+func (c *EnvCollector) Str(key, defaultValue string) string {
+	return c.helpers().Str(key, defaultValue)
+}
+
+// StrS returns the env var value as []string. See EnvStrS()
+func (c *EnvCollector) StrS(key, separator string, defaultValue []string) []string {
+	return c.helpers().StrS(key, separator, defaultValue)
+}
+
+// Int returns the env var value as int, keeping the error when it's malformed. See EnvIntE()
+func (c *EnvCollector) Int(key string, defaultValue int) int {
+	i, err := c.helpers().IntE(key, defaultValue)
+
+	c.add(err)
+
+	return i
+}
+
+// Int64 returns the env var value as int64, keeping the error when it's malformed. See EnvInt64E()
+func (c *EnvCollector) Int64(key string, defaultValue int64) int64 {
+	i, err := c.helpers().Int64E(key, defaultValue)
+
+	c.add(err)
+
+	return i
+}
+
+// IntS returns the env var value as []int, keeping the error when it's malformed. See EnvIntSE()
+func (c *EnvCollector) IntS(key, separator string, defaultValue []int) []int {
+	is, err := c.helpers().IntSE(key, separator, defaultValue)
+
+	c.add(err)
+
+	return is
+}
+
+// Float64 returns the env var value as float64, keeping the error when it's malformed. See EnvFloat64E()
+func (c *EnvCollector) Float64(key string, defaultValue float64) float64 {
+	f, err := c.helpers().Float64E(key, defaultValue)
+
+	c.add(err)
+
+	return f
+}
+
+// Bool returns the env var value as boolean, keeping the error when it's malformed. See EnvBoolE()
+func (c *EnvCollector) Bool(key string, defaultValue bool) bool {
+	b, err := c.helpers().BoolE(key, defaultValue)
+
+	c.add(err)
+
+	return b
+}
+
+// Errors returns the errors kept so far
+func (c *EnvCollector) Errors() EnvErrors {
+	return c.errs
+}
+
+// Err returns nil when every variable was read successfully, or EnvErrors with all the problems found
+func (c *EnvCollector) Err() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	return c.errs
+}
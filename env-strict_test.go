@@ -0,0 +1,102 @@
+package handy
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEnvStrictGetters(t *testing.T) {
+	defer envTestSet(t, map[string]string{
+		"STRICT_INT":       " 42 ",
+		"STRICT_BAD_INT":   "42x",
+		"STRICT_INTS":      "1, 2,3",
+		"STRICT_BAD_INTS":  "1,x,3",
+		"STRICT_FLOAT":     "2.5",
+		"STRICT_BAD_FLOAT": "2,5",
+		"STRICT_BOOL":      "true",
+		"STRICT_BAD_BOOL":  "yes",
+	})()
+
+	tcs := []struct {
+		summary        string
+		get            func() (interface{}, error)
+		expectedOutput interface{}
+		expectedError  bool
+	}{
+		{"int", func() (interface{}, error) { return EnvIntE("STRICT_INT", 7) }, 42, false},
+		{"malformed int", func() (interface{}, error) { return EnvIntE("STRICT_BAD_INT", 7) }, 7, true},
+		{"unset int", func() (interface{}, error) { return EnvIntE("STRICT_UNSET", 7) }, 7, false},
+		{"int64", func() (interface{}, error) { return EnvInt64E("STRICT_INT", 7) }, int64(42), false},
+		{"malformed int64", func() (interface{}, error) { return EnvInt64E("STRICT_BAD_INT", 7) }, int64(7), true},
+		{"ints", func() (interface{}, error) { return EnvIntSE("STRICT_INTS", ",", nil) }, []int{1, 2, 3}, false},
+		{"malformed ints", func() (interface{}, error) { return EnvIntSE("STRICT_BAD_INTS", ",", []int{9}) }, []int{9}, true},
+		{"unset ints", func() (interface{}, error) { return EnvIntSE("STRICT_UNSET", ",", nil) }, []int{}, false},
+		{"float", func() (interface{}, error) { return EnvFloat64E("STRICT_FLOAT", 1) }, 2.5, false},
+		{"malformed float", func() (interface{}, error) { return EnvFloat64E("STRICT_BAD_FLOAT", 1) }, 1.0, true},
+		{"bool", func() (interface{}, error) { return EnvBoolE("STRICT_BOOL", false) }, true, false},
+		{"malformed bool", func() (interface{}, error) { return EnvBoolE("STRICT_BAD_BOOL", false) }, false, true},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr, err := tc.get()
+
+			if !reflect.DeepEqual(tr, tc.expectedOutput) || (err != nil) != tc.expectedError {
+				t.Errorf("Test has failed!\n\tExpected: %v, error %v, \n\tGot: %v, %v", tc.expectedOutput, tc.expectedError, tr, err)
+			}
+
+			if err != nil && !errors.Is(err, ErrEnvMalformed) {
+				t.Errorf("Test has failed!\n\tExpected ErrEnvMalformed, got %v", err)
+			}
+		})
+	}
+
+	if is := EnvIntS("STRICT_BAD_INTS", ",", []int{9}); !reflect.DeepEqual(is, []int{9}) {
+		t.Errorf("Test has failed!\n\tExpected the default value for a malformed list, got %v", is)
+	}
+}
+
+func TestEnvCollector(t *testing.T) {
+	defer envTestSet(t, map[string]string{
+		"COLLECT_PORT":  "8080",
+		"COLLECT_RATIO": "half",
+		"COLLECT_IDS":   "1,x",
+		"COLLECT_NAME":  "billing",
+	})()
+
+	var c EnvCollector
+
+	port := c.Int("COLLECT_PORT", 80)
+	ratio := c.Float64("COLLECT_RATIO", 0.5)
+	ids := c.IntS("COLLECT_IDS", ",", nil)
+	name := c.Required("COLLECT_NAME")
+	c.Required("COLLECT_DSN")
+	debug := c.Bool("COLLECT_DEBUG", true)
+
+	if port != 8080 || ratio != 0.5 || len(ids) != 0 || name != "billing" || !debug {
+		t.Errorf("Test has failed!\n\tGot: %v %v %v %v %v", port, ratio, ids, name, debug)
+	}
+
+	var errs EnvErrors
+
+	if err := c.Err(); !errors.As(err, &errs) {
+		t.Fatalf("Test has failed!\n\tExpected EnvErrors, got %v", err)
+	}
+
+	if expected := []string{"COLLECT_RATIO", "COLLECT_IDS", "COLLECT_DSN"}; !reflect.DeepEqual(errs.Vars(), expected) {
+		t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", expected, errs.Vars())
+	}
+
+	if !errors.Is(errs[2], ErrEnvMissing) {
+		t.Errorf("Test has failed!\n\tExpected ErrEnvMissing, got %v", errs[2])
+	}
+
+	var ok EnvCollector
+
+	ok.Int("COLLECT_PORT", 80)
+
+	if err := ok.Err(); err != nil {
+		t.Errorf("Test has failed!\n\tExpected nil, got %v", err)
+	}
+}
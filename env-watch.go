@@ -0,0 +1,261 @@
+package handy
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrEnvReadOnly is returned by Set() and Unset() of environments that can't be changed, like EnvWatcher
+var ErrEnvReadOnly = errors.New("handy: read-only environment")
+
+// EnvChange describes a variable that changed between two loads of the watched files
+type EnvChange struct {
+	Key string
+	// Old is the previous value, empty when the variable was added
+	Old string
+	// New is the current value, empty when the variable was removed
+	New     string
+	Added   bool
+	Removed bool
+}
+
+// EnvWatcher keeps the variables of dotenv files up to date, polling them for changes
+// It's an Env, so the getters can read the current values: handy.WithEnv(w).Bool("FEATURE_X", false),
+// or, for the package-level functions, handy.EnvSourceSet(handy.NewLayeredEnv(w, handy.OSEnv{})).
+// The values are replaced atomically, so concurrent readers always see a consistent set.
+type EnvWatcher struct {
+	files   []string
+	helpers EnvHelpers
+	vars    atomic.Value // map[string]string, never changed after stored
+
+	mu        sync.Mutex
+	contents  [][]byte
+	err       error
+	callbacks []func(changes []EnvChange)
+	channels  []chan []EnvChange
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// EnvWatch loads the dotenv files, with the same rules of EnvReadFiles(), and checks them for changes every interval
+// The files aren't applied to the process environment; read them through the watcher, that is an Env.
+// It fails when the files can't be loaded at first. Later errors keep the last good values, and are returned by Err().
+// Call Stop() to end the polling.
+func EnvWatch(interval time.Duration, files ...string) (*EnvWatcher, error) {
+	return packageEnvHelpers().Watch(interval, files...)
+}
+
+// Watch starts an EnvWatcher whose interpolations read the bound environment. See EnvWatch()
+func (h EnvHelpers) Watch(interval time.Duration, files ...string) (*EnvWatcher, error) {
+	if interval <= 0 {
+		return nil, errors.New("handy: EnvWatch() requires a positive interval")
+	}
+
+	w := &EnvWatcher{files: files, helpers: h, stop: make(chan struct{}), done: make(chan struct{})}
+	w.vars.Store(map[string]string{})
+
+	if _, err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	go w.poll(interval)
+
+	return w, nil
+}
+
+func (w *EnvWatcher) poll(interval time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			_, _ = w.Reload()
+		}
+	}
+}
+
+// Stop ends the polling. Values remain readable. It's safe to call it more than once, but not from an OnChange() callback
+func (w *EnvWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		for _, ch := range w.channels {
+			close(ch)
+		}
+
+		w.channels = nil
+	})
+}
+
+// Reload checks the files now, instead of waiting for the next poll, returning the changes found
+// When the files didn't change, it returns nil without parsing them again.
+func (w *EnvWatcher) Reload() ([]EnvChange, error) {
+	changes, callbacks, err := w.reload()
+
+	// callbacks run without the lock, so they may call the watcher
+	for _, fn := range callbacks {
+		fn(changes)
+	}
+
+	return changes, err
+}
+
+// reload parses the files when they changed, returning the changes and the callbacks to be notified
+func (w *EnvWatcher) reload() ([]EnvChange, []func(changes []EnvChange), error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	contents, err := envReadContents(w.files)
+
+	if err == nil && w.contents != nil && envSameContents(contents, w.contents) {
+		return nil, nil, w.err
+	}
+
+	var vars map[string]string
+
+	if err == nil {
+		vars, err = w.helpers.parseContents(w.files, contents)
+	}
+
+	w.err = err
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.contents = contents
+
+	changes := envDiff(w.vars.Load().(map[string]string), vars)
+
+	w.vars.Store(vars)
+
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+
+	for _, ch := range w.channels {
+		select {
+		case ch <- changes:
+		default:
+		}
+	}
+
+	return changes, append([]func(changes []EnvChange){}, w.callbacks...), nil
+}
+
+func envSameContents(a, b [][]byte) bool {
+	for i := range a {
+		if (a[i] == nil) != (b[i] == nil) || !bytes.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// envDiff returns the changes from old to current, sorted by key
+func envDiff(old, current map[string]string) []EnvChange {
+	var changes []EnvChange
+
+	for k, v := range current {
+		if ov, ok := old[k]; !ok {
+			changes = append(changes, EnvChange{Key: k, New: v, Added: true})
+		} else if ov != v {
+			changes = append(changes, EnvChange{Key: k, Old: ov, New: v})
+		}
+	}
+
+	for k, v := range old {
+		if _, ok := current[k]; !ok {
+			changes = append(changes, EnvChange{Key: k, Old: v, Removed: true})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	return changes
+}
+
+// OnChange registers a function called with the changes after each reload that finds any
+// It's called from the polling goroutine, or from Reload(), that wait for it to return.
+func (w *EnvWatcher) OnChange(fn func(changes []EnvChange)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.callbacks = append(w.callbacks, fn)
+}
+
+// Changes returns a channel receiving the changes after each reload that finds any, closed by Stop()
+// The channel has a small buffer; when the reader falls behind, changes are dropped. Vars() always has the current values.
+func (w *EnvWatcher) Changes() <-chan []EnvChange {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan []EnvChange, 8)
+
+	select {
+	case <-w.stop:
+		close(ch)
+	default:
+		w.channels = append(w.channels, ch)
+	}
+
+	return ch
+}
+
+// Err returns the error of the last reload, or nil when it succeeded
+func (w *EnvWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.err
+}
+
+// Vars returns a copy of the current variables
+func (w *EnvWatcher) Vars() map[string]string {
+	return w.Environ()
+}
+
+// Lookup returns the current value of a variable, and false when the files don't define it
+func (w *EnvWatcher) Lookup(key string) (string, bool) {
+	v, ok := w.vars.Load().(map[string]string)[key]
+
+	return v, ok
+}
+
+// Set returns ErrEnvReadOnly, as the values come from the files
+func (w *EnvWatcher) Set(key, value string) error {
+	return ErrEnvReadOnly
+}
+
+// Unset returns ErrEnvReadOnly, as the values come from the files
+func (w *EnvWatcher) Unset(key string) error {
+	return ErrEnvReadOnly
+}
+
+// Environ returns a copy of the current variables
+func (w *EnvWatcher) Environ() map[string]string {
+	vars := w.vars.Load().(map[string]string)
+	m := make(map[string]string, len(vars))
+
+	for k, v := range vars {
+		m[k] = v
+	}
+
+	return m
+}
@@ -0,0 +1,157 @@
+package handy
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// envWatchTestFiles returns the paths of .env and .env.local in a temporary directory, and a function writing them
+func envWatchTestFiles(t *testing.T) (base, local string, write func(fileName, content string), cleanup func()) {
+	dir, err := ioutil.TempDir("", "handy-env-watch")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write = func(fileName, content string) {
+		if err := ioutil.WriteFile(fileName, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return filepath.Join(dir, ".env"), filepath.Join(dir, ".env.local"), write, func() { os.RemoveAll(dir) }
+}
+
+func TestEnvWatchReload(t *testing.T) {
+	base, local, write, cleanup := envWatchTestFiles(t)
+	defer cleanup()
+
+	write(base, "FEATURE_X=false\nFEATURE_Y=true\nWORKERS=4")
+
+	// a long interval, so only Reload() checks the files
+	w, err := EnvWatch(time.Hour, base, local)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.Stop()
+
+	h := WithEnv(NewLayeredEnv(w, NewMapEnv(map[string]string{"WORKERS": "1", "REGION": "sa-east-1"})))
+
+	if h.Bool("FEATURE_X", true) || h.Int("WORKERS", 0) != 4 || h.Str("REGION", "") != "sa-east-1" {
+		t.Errorf("Test has failed!\n\tGot: %v", w.Vars())
+	}
+
+	var called []EnvChange
+
+	w.OnChange(func(changes []EnvChange) { called = changes })
+
+	ch := w.Changes()
+
+	write(local, "FEATURE_X=true\nBATCH=100")
+	write(base, "FEATURE_X=false\nWORKERS=4")
+
+	expected := []EnvChange{
+		{Key: "BATCH", New: "100", Added: true},
+		{Key: "FEATURE_X", Old: "false", New: "true"},
+		{Key: "FEATURE_Y", Old: "true", Removed: true},
+	}
+
+	if changes, err := w.Reload(); err != nil || !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v, %v", expected, changes, err)
+	}
+
+	if !reflect.DeepEqual(called, expected) || !reflect.DeepEqual(<-ch, expected) {
+		t.Errorf("Test has failed! OnChange() and Changes() should receive the changes")
+	}
+
+	if !h.Bool("FEATURE_X", false) || h.Int("BATCH", 0) != 100 {
+		t.Errorf("Test has failed!\n\tGot: %v", w.Vars())
+	}
+
+	if changes, err := w.Reload(); changes != nil || err != nil {
+		t.Errorf("Test has failed!\n\tExpected no changes, got %v, %v", changes, err)
+	}
+
+	write(local, "BROKEN LINE")
+
+	if _, err := w.Reload(); !errors.Is(err, ErrEnvSyntax) || !errors.Is(w.Err(), ErrEnvSyntax) {
+		t.Errorf("Test has failed!\n\tExpected ErrEnvSyntax, got %v", err)
+	}
+
+	if !h.Bool("FEATURE_X", false) {
+		t.Errorf("Test has failed! A malformed file should keep the last good values")
+	}
+
+	if err := w.Set("FEATURE_X", "false"); err != ErrEnvReadOnly {
+		t.Errorf("Test has failed!\n\tExpected ErrEnvReadOnly, got %v", err)
+	}
+
+	w.Stop()
+
+	if _, open := <-ch; open {
+		t.Errorf("Test has failed! Changes() should be closed by Stop()")
+	}
+}
+
+func TestEnvWatchPolling(t *testing.T) {
+	base, _, write, cleanup := envWatchTestFiles(t)
+	defer cleanup()
+
+	write(base, "FEATURE_X=false")
+
+	w, err := EnvWatch(5*time.Millisecond, base)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.Stop()
+
+	ch := w.Changes()
+
+	write(base, "FEATURE_X=true")
+
+	select {
+	case changes := <-ch:
+		if len(changes) != 1 || changes[0].New != "true" {
+			t.Errorf("Test has failed!\n\tGot: %v", changes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Test has failed! The change wasn't picked up")
+	}
+
+	if v, _ := w.Lookup("FEATURE_X"); v != "true" {
+		t.Errorf("Test has failed!\n\tExpected: true, \n\tGot: %s", v)
+	}
+
+	write(base, "BROKEN LINE")
+
+	if _, err := EnvWatch(time.Second, base); !errors.Is(err, ErrEnvSyntax) {
+		t.Errorf("Test has failed!\n\tExpected ErrEnvSyntax, got %v", err)
+	}
+
+	if _, err := EnvWatch(0, base); err == nil {
+		t.Errorf("Test has failed! EnvWatch() should require a positive interval")
+	}
+}
+
+func TestEnvDiff(t *testing.T) {
+	tr := envDiff(map[string]string{"A": "1", "B": "2", "C": "3"}, map[string]string{"A": "1", "B": "20", "D": "4"})
+
+	expected := []EnvChange{
+		{Key: "B", Old: "2", New: "20"},
+		{Key: "C", Old: "3", Removed: true},
+		{Key: "D", New: "4", Added: true},
+	}
+
+	if !reflect.DeepEqual(tr, expected) {
+		t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", expected, tr)
+	}
+}
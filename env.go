@@ -4,9 +4,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"os"
-	"regexp"
-	"strconv"
 	"strings"
 )
 
@@ -26,13 +23,19 @@ func debugLog(msg string, debugPrint bool) {
 
 // EnvCheck Test environment variables
 func EnvCheck(varName, defaultValue string, mandatory, debugPrint bool) error {
-	if os.Getenv(varName) != `` {
+	return packageEnvHelpers().Check(varName, defaultValue, mandatory, debugPrint)
+}
+
+// Check tests an environment variable, defining it with the default value when it isn't set. See EnvCheck()
+// The debug output shows only the variable name, never its value.
+func (h EnvHelpers) Check(varName, defaultValue string, mandatory, debugPrint bool) error {
+	if h.get(varName) != `` {
 		debugLog(fmt.Sprintf(`environment variable "%s" asserted`, varName), debugPrint)
 		return nil
 	}
 
 	if defaultValue != `` {
-		if err := os.Setenv(varName, defaultValue); err != nil {
+		if err := h.env.Set(varName, defaultValue); err != nil {
 			return nil
 		}
 
@@ -49,8 +52,13 @@ func EnvCheck(varName, defaultValue string, mandatory, debugPrint bool) error {
 
 // EnvStr returns the env var value as string
 func EnvStr(key, defaultValue string) string {
-	if os.Getenv(key) != `` {
-		return os.Getenv(key)
+	return packageEnvHelpers().Str(key, defaultValue)
+}
+
+// Str returns the env var value as string. See EnvStr()
+func (h EnvHelpers) Str(key, defaultValue string) string {
+	if v := h.get(key); v != `` {
+		return v
 	}
 
 	return defaultValue
@@ -58,8 +66,13 @@ func EnvStr(key, defaultValue string) string {
 
 // EnvStrS returns the env var value as []string (string slice)
 func EnvStrS(key, separator string, defaultValue []string) []string {
-	if os.Getenv(key) != `` {
-		return strings.Split(os.Getenv(key), separator)
+	return packageEnvHelpers().StrS(key, separator, defaultValue)
+}
+
+// StrS returns the env var value as []string. See EnvStrS()
+func (h EnvHelpers) StrS(key, separator string, defaultValue []string) []string {
+	if v := h.get(key); v != `` {
+		return strings.Split(v, separator)
 	}
 
 	if len(defaultValue) > 0 {
@@ -70,68 +83,68 @@ func EnvStrS(key, separator string, defaultValue []string) []string {
 }
 
 // EnvInt returns the env var value as int
+// Malformed values are ignored, returning the default value. See EnvIntE()
 func EnvInt(key string, defaultValue int) int {
-	if os.Getenv(key) != `` {
-		if i, err := strconv.Atoi(os.Getenv(key)); err == nil {
-			return i
-		}
-	}
+	return packageEnvHelpers().Int(key, defaultValue)
+}
 
-	return defaultValue
+// Int returns the env var value as int. See EnvInt()
+func (h EnvHelpers) Int(key string, defaultValue int) int {
+	i, _ := h.IntE(key, defaultValue)
+
+	return i
 }
 
 // EnvInt64 returns the env var value as int64
+// Malformed values are ignored, returning the default value. See EnvInt64E()
 func EnvInt64(key string, defaultValue int64) int64 {
-	if os.Getenv(key) != `` {
-		if i, err := strconv.ParseInt(os.Getenv(key), 10, 64); err == nil {
-			return i
-		}
-	}
+	return packageEnvHelpers().Int64(key, defaultValue)
+}
 
-	return defaultValue
+// Int64 returns the env var value as int64. See EnvInt64()
+func (h EnvHelpers) Int64(key string, defaultValue int64) int64 {
+	i, _ := h.Int64E(key, defaultValue)
+
+	return i
 }
 
 // EnvIntS returns the env var value as []int
+// Malformed values are ignored, returning the default value. See EnvIntSE()
 func EnvIntS(key, separator string, defaultValue []int) []int {
-	if os.Getenv(key) != `` {
-		a := strings.Split(os.Getenv(key), separator)
-
-		is := make([]int, len(a))
-
-		for i, x := range a {
-			is[i], _ = strconv.Atoi(x)
-		}
-
-		return is
-	}
+	return packageEnvHelpers().IntS(key, separator, defaultValue)
+}
 
-	if len(defaultValue) > 0 {
-		return defaultValue
-	}
+// IntS returns the env var value as []int. See EnvIntS()
+func (h EnvHelpers) IntS(key, separator string, defaultValue []int) []int {
+	is, _ := h.IntSE(key, separator, defaultValue)
 
-	return []int{}
+	return is
 }
 
 // EnvFloat64 returns the env var value as float64
+// Malformed values are ignored, returning the default value. See EnvFloat64E()
 func EnvFloat64(key string, defaultValue float64) float64 {
-	if os.Getenv(key) != `` {
-		if f, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
-			return f
-		}
-	}
+	return packageEnvHelpers().Float64(key, defaultValue)
+}
 
-	return defaultValue
+// Float64 returns the env var value as float64. See EnvFloat64()
+func (h EnvHelpers) Float64(key string, defaultValue float64) float64 {
+	f, _ := h.Float64E(key, defaultValue)
+
+	return f
 }
 
 // EnvBool returns the env var value as boolean
+// Malformed values are ignored, returning the default value. See EnvBoolE()
 func EnvBool(key string, defaultValue bool) bool {
-	if os.Getenv(key) != `` {
-		if b, err := strconv.ParseBool(os.Getenv(key)); err == nil {
-			return b
-		}
-	}
+	return packageEnvHelpers().Bool(key, defaultValue)
+}
 
-	return defaultValue
+// Bool returns the env var value as boolean. See EnvBool()
+func (h EnvHelpers) Bool(key string, defaultValue bool) bool {
+	b, _ := h.BoolE(key, defaultValue)
+
+	return b
 }
 
 // EnvCheckerNew returns a new instance of EnvChecker to be used with EnvCheckMany()
@@ -146,8 +159,13 @@ func EnvCheckerNew(varName, defaultValue string, mandatory, debugPrint bool) Env
 
 // EnvCheckMany Test multiple environment variables at once
 func EnvCheckMany(envCheckers []EnvChecker) error {
+	return packageEnvHelpers().CheckMany(envCheckers)
+}
+
+// CheckMany tests multiple environment variables at once. See EnvCheckMany()
+func (h EnvHelpers) CheckMany(envCheckers []EnvChecker) error {
 	for _, c := range envCheckers {
-		if err := EnvCheck(c.VarName, c.DefaultValue, c.Mandatory, c.DebugPrint); err != nil {
+		if err := h.Check(c.VarName, c.DefaultValue, c.Mandatory, c.DebugPrint); err != nil {
 			return err
 		}
 	}
@@ -155,13 +173,17 @@ func EnvCheckMany(envCheckers []EnvChecker) error {
 	return nil
 }
 
-var reEnvVarRow = regexp.MustCompile(`^([A-Za-z][0-9A-Za-z_]*)=(\S+)`)
-
-// EnvLoadFromDisk loads a file from disk, containing variables written in KEY=VALUE format
+// EnvLoadFromDisk loads a file from disk, containing variables written in dotenv format. See EnvParse() for the syntax
 // fileName is the file name with complete path
 // mustHave forces an error if the file doesn't exist
 // overwriteValue when false, makes the engine skip env vars that are already definied
+// Malformed lines are skipped. Use EnvLoadFiles() to get an error instead.
 func EnvLoadFromDisk(fileName string, mustHave, overwriteValues bool) error {
+	return packageEnvHelpers().LoadFromDisk(fileName, mustHave, overwriteValues)
+}
+
+// LoadFromDisk loads a dotenv file into the bound environment. See EnvLoadFromDisk()
+func (h EnvHelpers) LoadFromDisk(fileName string, mustHave, overwriteValues bool) error {
 	content, err := ioutil.ReadFile(fileName)
 
 	if err != nil {
@@ -172,34 +194,11 @@ func EnvLoadFromDisk(fileName string, mustHave, overwriteValues bool) error {
 		return nil
 	}
 
-	text := string(content)
-
-	text = strings.ReplaceAll(text, "\r\n", "\n")
-
-	rows := strings.Split(text, "\n")
-
-	for _, row := range rows {
-		row = strings.TrimSpace(row)
-
-		matches := reEnvVarRow.FindStringSubmatch(row)
-
-		if len(matches) != 3 {
-			continue
-		}
+	vars := map[string]string{}
 
-		key := matches[1]
-		value := matches[2]
-
-		if os.Getenv(key) != "" {
-			if !overwriteValues {
-				continue
-			}
-		}
-
-		if err := os.Setenv(key, value); err != nil {
-			return err
-		}
+	if err := envParse(string(content), fileName, vars, true, h.get); err != nil {
+		return err
 	}
 
-	return nil
+	return h.setAll(vars, overwriteValues)
 }
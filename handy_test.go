@@ -1060,3 +1060,32 @@ func TestPositiveOrZero(t *testing.T) {
 		})
 	}
 }
+
+func TestElapsedTime(t *testing.T) {
+	tcs := []struct {
+		summary  string
+		from     time.Time
+		to       time.Time
+		expected [6]int
+	}{
+		{"fraction of second is ignored", time.Date(2020, 1, 1, 0, 0, 0, 500000000, time.UTC), time.Date(2020, 1, 1, 0, 0, 1, 0, time.UTC), [6]int{0, 0, 0, 0, 0, 1}},
+		{"fraction doesn't borrow a month", time.Date(2020, 1, 1, 0, 0, 0, 500000000, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), [6]int{0, 1, 0, 0, 0, 0}},
+		{"reversed", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), [6]int{1, 2, 3, 5, 6, 7}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			var tr [6]int
+
+			tr[0], tr[1], tr[2], tr[3], tr[4], tr[5] = ElapsedTime(tc.from, tc.to)
+
+			if tr != tc.expected {
+				t.Errorf("Test has failed!\n\tExpected: %v, \n\tGot: %v", tc.expected, tr)
+			}
+		})
+	}
+
+	if ElapsedMonths(time.Date(2020, 1, 1, 0, 0, 0, 500000000, time.UTC), time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)) != 1 {
+		t.Errorf("Test has failed! ElapsedMonths() should ignore fractions of second too")
+	}
+}
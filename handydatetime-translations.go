@@ -1,28 +1,20 @@
 package handy
 
 // DateStrCheckErrMessage translates the given error code into a plain string message
+// The routine considers the given idiom, a BCP-47 tag like "pt-BR" or the legacy "bra". The fallback is in english
+// Messages come from DefaultCatalog. DateStrCheckOk and unknown codes return an empty string
 func DateStrCheckErrMessage(idiom string, errCode DateStrCheck) string {
-	if idiom == "bra" {
-		switch errCode {
-		case DateStrCheckErrInvalid:
-			return "data ou formato inválido"
-		case DateStrCheckErrOutOfRange:
-			return "data fora do intervalo permitido"
-		case DateStrCheckErrEmpty:
-			return "data não definida"
-		default:
-			return ""
-		}
+	keys := map[DateStrCheck]string{
+		DateStrCheckErrInvalid:    "date.invalid",
+		DateStrCheckErrOutOfRange: "date.out_of_range",
+		DateStrCheckErrEmpty:      "date.empty",
 	}
 
-	switch errCode {
-	case DateStrCheckErrInvalid:
-		return "date or format invalid"
-	case DateStrCheckErrOutOfRange:
-		return "date out of range"
-	case DateStrCheckErrEmpty:
-		return "date undefined"
-	default:
+	key, ok := keys[errCode]
+
+	if !ok {
 		return ""
 	}
+
+	return DefaultCatalog.Translate(idiom, key, nil)
 }
@@ -1,70 +1,128 @@
 package handy
 
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
 // CheckPersonNameResult returns a meaningful message describing the code generated bu CheckPersonName
-// The routine considers the given idiom. The fallback is in english
+// The routine considers the given idiom, a BCP-47 tag like "pt-BR" or the legacy "bra". The fallback is in english
+// Messages come from DefaultCatalog
 func CheckPersonNameResult(idiom string, r uint8) string {
-	switch idiom {
-	case "bra":
-		switch r {
-		case CheckPersonNameResultOK:
-			return "Nome Válido"
-		case CheckPersonNameResultPolluted:
-			return "O campo nome permite apenas letras e espaços"
-		case CheckPersonNameResultTooFewWords:
-			return "O nome deve ser composto de ao menos duas palavras"
-		case CheckPersonNameResultTooShort:
-			return "O nome deve ser composto de ao menos duas palavras, sendo uma com três e outra com ao menos duas letras"
-		case CheckPersonNameResultTooSimple:
-			return "Nome muito curto ou vazio"
-		default:
-			return "Erro desconhecido"
-		}
-	default:
-		switch r {
-		case CheckPersonNameResultOK:
-			return "Name is well formed"
-		case CheckPersonNameResultPolluted:
-			return "Name accepts only letters and spaces"
-		case CheckPersonNameResultTooFewWords:
-			return "Name should be composed by at least two words"
-		case CheckPersonNameResultTooShort:
-			return "Name should be composed by at least two words, been one with 2 and the other with at least 3 letters"
-		case CheckPersonNameResultTooSimple:
-			return "Name too short or empty"
-		default:
-			return "Unknow error"
-		}
+	keys := map[uint8]string{
+		CheckPersonNameResultOK:          "personname.ok",
+		CheckPersonNameResultPolluted:    "personname.polluted",
+		CheckPersonNameResultTooFewWords: "personname.too_few_words",
+		CheckPersonNameResultTooShort:    "personname.too_short",
+		CheckPersonNameResultTooSimple:   "personname.too_simple",
 	}
+
+	return translateCode(idiom, keys[r])
 }
 
 // CheckNewPasswordResult returns a meaningful message describing the code generated bu CheckNewPassword()
-// The routine considers the given idiom. The fallback is in english
+// The routine considers the given idiom, a BCP-47 tag like "pt-BR" or the legacy "bra". The fallback is in english
+// Messages come from DefaultCatalog
 func CheckNewPasswordResult(idiom string, r uint8) string {
-	if idiom == "bra" {
-		switch r {
-		case CheckNewPasswordResultOK:
-			return "Senha Válida"
-		case CheckNewPasswordResultDivergent:
-			return "Senha diferente da confirmação"
-		case CheckNewPasswordResultTooShort:
-			return "Senha deve conter ao menos 6 caracteres, entre números e letras"
-		case CheckNewPasswordResultTooSimple:
-			return "Senha deve conter ao menos 6 caracteres, entre números e letras"
-		default:
-			return "Erro desconhecido"
-		}
-	} else {
-		switch r {
-		case CheckNewPasswordResultOK:
-			return "Password Validated"
-		case CheckNewPasswordResultDivergent:
-			return "Password and confirmation doesn't match"
-		case CheckNewPasswordResultTooShort:
-			return "Password should contains at least 6 characters, mixing letters and numbers"
-		case CheckNewPasswordResultTooSimple:
-			return "Password should contains at least 6 characters, mixing letters and numbers"
-		default:
-			return "Unknow error"
+	keys := map[uint8]string{
+		CheckNewPasswordResultOK:        "password.ok",
+		CheckNewPasswordResultDivergent: "password.divergent",
+		CheckNewPasswordResultTooShort:  "password.too_short",
+		CheckNewPasswordResultTooSimple: "password.too_simple",
+	}
+
+	return translateCode(idiom, keys[r])
+}
+
+// checkStrKeys maps CheckStr() codes to DefaultCatalog keys
+var checkStrKeys = map[int8]string{
+	CheckStrOk:                      "checkstr.ok",
+	CheckStrEmptyDenied:             "checkstr.empty_denied",
+	CheckStrTooShort:                "checkstr.too_short",
+	CheckStrTooLong:                 "checkstr.too_long",
+	CheckStrSpaceDenied:             "checkstr.space_denied",
+	CheckStrNubersDenied:            "checkstr.numbers_denied",
+	CheckStrLettersDenied:           "checkstr.letters_denied",
+	CheckStrSymbolsDenied:           "checkstr.symbols_denied",
+	CheckStrMoreThanOneWordDenied:   "checkstr.more_than_one_word_denied",
+	CheckStrUpperCaseDenied:         "checkstr.uppercase_denied",
+	CheckStrLowercaseDenied:         "checkstr.lowercase_denied",
+	CheckStrUnicodeDenied:           "checkstr.unicode_denied",
+	CheckStrNumbersNotFound:         "checkstr.numbers_not_found",
+	CheckStrLettersNotFound:         "checkstr.letters_not_found",
+	CheckStrSymbolsNotFound:         "checkstr.symbols_not_found",
+	CheckStrMoreThanOneWordNotFound: "checkstr.more_than_one_word_not_found",
+	CheckStrUpperCaseNotFound:       "checkstr.uppercase_not_found",
+	CheckStrLowercaseNotFound:       "checkstr.lowercase_not_found",
+}
+
+// CheckStrResult returns a meaningful message describing the code generated by CheckStr()
+// The routine considers the given idiom, a BCP-47 tag like "pt-BR" or the legacy "bra". The fallback is in english
+// Messages come from DefaultCatalog
+func CheckStrResult(idiom string, r int8) string {
+	return translateCode(idiom, checkStrKeys[r])
+}
+
+// translateCode returns the catalog message for the key, or the "unknown error" message when the key is empty
+func translateCode(idiom, key string) string {
+	if key == "" {
+		key = "unknown_error"
+	}
+
+	return DefaultCatalog.Translate(idiom, key, nil)
+}
+
+// checkStrViolationMessage translates a CheckStrViolation, adding the length limits when they matter
+func checkStrViolationMessage(idiom string, v CheckStrViolation) string {
+	msg := CheckStrResult(idiom, v.Code)
+
+	switch v.Code {
+	case CheckStrTooShort:
+		return DefaultCatalog.TranslatePlural(idiom, "checkstr.too_short_min", int(v.Min), CatalogParams{"message": msg})
+	case CheckStrTooLong:
+		return DefaultCatalog.TranslatePlural(idiom, "checkstr.too_long_max", int(v.Max), CatalogParams{"message": msg})
+	}
+
+	return msg
+}
+
+// validationErrorMessage translates a ValidationError according the given idiom. The fallback is in english
+// Rules are translated by the "validation.<rule>" catalog key, so custom rules can be translated too.
+// Errors from custom rules without a catalog entry are returned as they are
+func validationErrorMessage(idiom string, e ValidationError) string {
+	var (
+		pn  validationPersonNameError
+		ds  validationDateError
+		cse CheckStrErrors
+	)
+
+	switch {
+	case errors.As(e.Err, &pn):
+		return CheckPersonNameResult(idiom, uint8(pn))
+	case errors.As(e.Err, &cse):
+		return strings.Join(cse.Messages(idiom), "; ")
+	case errors.As(e.Err, &ds) && e.Rule == "date":
+		return DateStrCheckErrMessage(idiom, DateStrCheck(ds))
+	case e.Err == errValidationType:
+		return DefaultCatalog.Translate(idiom, "validation.type", CatalogParams{"rule": e.Rule})
+	}
+
+	key := "validation." + e.Rule
+
+	params := CatalogParams{"rule": e.Rule, "param": e.Param, "options": strings.Replace(e.Param, "|", ", ", -1)}
+
+	if DefaultCatalog.Has(idiom, key) {
+		if n, err := strconv.Atoi(e.Param); err == nil {
+			return DefaultCatalog.TranslatePlural(idiom, key, n, params)
 		}
+
+		return DefaultCatalog.Translate(idiom, key, params)
 	}
+
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+
+	return DefaultCatalog.Translate(idiom, "validation.invalid", params)
 }
@@ -0,0 +1,418 @@
+package handy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Struct tags read by HTTPBind(), besides "default", "required" and "sep", shared with EnvLoad()
+// Example: `query:"page" default:"1"`, `path:"id" required:"true"`, `form:"birth" format:"dd/mm/yyyy"`
+const (
+	HTTPTagQuery  = "query"
+	HTTPTagForm   = "form"
+	HTTPTagPath   = "path"
+	HTTPTagHeader = "header"
+	HTTPTagFormat = "format"
+)
+
+// httpBindSources are the tags of the parameter sources, in the order they're checked on each field
+var httpBindSources = []string{HTTPTagPath, HTTPTagQuery, HTTPTagForm, HTTPTagHeader}
+
+// httpBindMaxMemory is the memory used by multipart forms, with files beyond it stored on disk
+const httpBindMaxMemory = 32 << 20
+
+// ErrHTTPParamMissing and ErrHTTPParamMalformed are wrapped by HTTPParamError, to be tested with errors.Is()
+var (
+	ErrHTTPParamMissing   = errors.New("handy: required parameter is missing")
+	ErrHTTPParamMalformed = errors.New("handy: malformed parameter")
+)
+
+// HTTPParamError describes a request parameter that couldn't be bound to a struct field
+type HTTPParamError struct {
+	// Source is the tag of the parameter source: "query", "form", "path" or "header". It may be empty for errors built by hand
+	Source string
+	// Param is the parameter name, as sent by the client
+	Param string
+	// Field is the field path, like "Filter.Page"
+	Field string
+	// Err wraps ErrHTTPParamMissing or ErrHTTPParamMalformed
+	Err error
+}
+
+// Error returns the parameter, its source and the reason
+func (e HTTPParamError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf(`parameter "%s": %v`, e.Param, e.Err)
+	}
+
+	return fmt.Sprintf(`%s parameter "%s": %v`, e.Source, e.Param, e.Err)
+}
+
+// Unwrap returns the reason, making errors.Is() work with ErrHTTPParamMissing and ErrHTTPParamMalformed
+func (e HTTPParamError) Unwrap() error {
+	return e.Err
+}
+
+// Message returns the reason for the client, without the "handy:" prefix of the sentinels
+func (e HTTPParamError) Message() string {
+	return strings.TrimPrefix(e.Err.Error(), "handy: ")
+}
+
+// HTTPParamErrors is the list of all the parameters HTTPBind() couldn't read
+type HTTPParamErrors []HTTPParamError
+
+// Error returns all the errors separated by semicolons
+func (e HTTPParamErrors) Error() string {
+	a := make([]string, len(e))
+
+	for i, v := range e {
+		a[i] = v.Error()
+	}
+
+	return strings.Join(a, "; ")
+}
+
+// Messages returns the error messages indexed by parameter name, ready to be answered with a 400 Bad Request
+// Example: {"page": "malformed parameter: expected an integer between ..."}
+func (e HTTPParamErrors) Messages() map[string]string {
+	m := make(map[string]string, len(e))
+
+	for _, v := range e {
+		m[v.Param] = v.Message()
+	}
+
+	return m
+}
+
+// Param returns the error for the given parameter name, if there's one
+func (e HTTPParamErrors) Param(name string) (HTTPParamError, bool) {
+	for _, v := range e {
+		if v.Param == name {
+			return v, true
+		}
+	}
+
+	return HTTPParamError{}, false
+}
+
+type httpPathParamsKey struct{}
+
+// HTTPWithPathParams returns a shallow copy of the request carrying the path parameters, to be read by HTTPBind()
+// Routers usually do it themselves; see HTTPPathParam to plug one in.
+func HTTPWithPathParams(r *http.Request, params map[string]string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), httpPathParamsKey{}, params))
+}
+
+// HTTPPathParam returns a path parameter of the request. HTTPBind() reads `path` tags through it
+// By default it reads the parameters given to HTTPWithPathParams(). It can be replaced at startup to use a router,
+// like handy.HTTPPathParam = chi.URLParam, or func(r *http.Request, key string) string { return mux.Vars(r)[key] }
+var HTTPPathParam = func(r *http.Request, key string) string {
+	params, _ := r.Context().Value(httpPathParamsKey{}).(map[string]string)
+
+	return params[key]
+}
+
+// HTTPBind fills a struct, given by pointer, with the request parameters, according the tags of its fields
+// Tags "query", "form", "path" and "header" give the parameter name and where it comes from. "form" reads only the body,
+// url-encoded or multipart, and "query" reads only the URL. A field may have more than one source; the first found wins,
+// in the order path, query, form and header.
+// Tag "default" gives the value used when the parameter is missing or empty, and tag "required", when true,
+// makes an error when it's missing and there's no default. Like in EnvLoad(), "required" is read by strconv.ParseBool(),
+// and an invalid value is a plain error.
+// Slices take all the values of a repeated key, like ?id=1&id=2. With a "sep" tag, each value is split too, like ?id=1,2.
+// time.Time fields are read with the handy format of the "format" tag, like "dd/mm/yyyy" (see DateTimeParse()), or RFC 3339.
+// Date fields use the "format" tag too, or yyyy-mm-dd. Booleans accept "on", as sent by HTML checkboxes.
+// Supported types are the same of EnvLoad(): string, bool, all integer and float types, time.Duration, url.URL,
+// types implementing encoding.TextUnmarshaler, and pointers and slices of them. Nested structs without tags are visited.
+// Every parameter is checked: the returned HTTPParamErrors holds all the missing and malformed ones.
+// A plain error is returned when the request body can't be parsed or the struct can't be bound, like for an unsupported type.
+func HTTPBind(r *http.Request, v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("handy: HTTPBind() requires a non-nil pointer to struct")
+	}
+
+	if err := httpParseBody(r); err != nil {
+		return err
+	}
+
+	query := r.URL.Query()
+
+	lookup := func(source, key string) []string {
+		switch source {
+		case HTTPTagPath:
+			if s := HTTPPathParam(r, key); s != "" {
+				return []string{s}
+			}
+
+			return nil
+		case HTTPTagQuery:
+			return query[key]
+		case HTTPTagForm:
+			return r.PostForm[key]
+		}
+
+		return r.Header[http.CanonicalHeaderKey(key)]
+	}
+
+	var errs HTTPParamErrors
+
+	if err := httpBindStruct(rv.Elem(), "", lookup, &errs); err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// httpParseBody parses the form, once per request, choosing the parser by the content type
+func httpParseBody(r *http.Request) error {
+	if r.PostForm != nil {
+		return nil
+	}
+
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct == "multipart/form-data" {
+		if err := r.ParseMultipartForm(httpBindMaxMemory); err != nil {
+			return fmt.Errorf("handy: can't parse the request body: %v", err)
+		}
+
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return fmt.Errorf("handy: can't parse the request body: %v", err)
+	}
+
+	return nil
+}
+
+func httpBindStruct(rv reflect.Value, path string, lookup func(source, key string) []string, errs *HTTPParamErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+
+		// Unexported fields are skipped
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		fv := rv.Field(i)
+		fieldPath := path + sf.Name
+
+		source, key := "", ""
+
+		for _, tag := range httpBindSources {
+			if name := sf.Tag.Get(tag); name != "" && name != "-" {
+				if source == "" {
+					source, key = tag, name
+				}
+
+				if values := lookup(tag, name); len(values) > 0 && values[0] != "" {
+					source, key = tag, name
+					break
+				}
+			}
+		}
+
+		if source == "" {
+			if err := httpBindNested(fv, fieldPath+".", lookup, errs); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		values := lookup(source, key)
+
+		if len(values) == 0 || values[0] == "" {
+			values = nil
+
+			if def, ok := sf.Tag.Lookup(EnvTagDefault); ok {
+				values = []string{def}
+			}
+		}
+
+		required := false
+
+		if r := sf.Tag.Get(EnvTagRequired); r != "" {
+			var err error
+
+			if required, err = strconv.ParseBool(r); err != nil {
+				return fmt.Errorf("handy: HTTPBind() field %s: invalid required tag %q", fieldPath, r)
+			}
+		}
+
+		if len(values) == 0 {
+			if required {
+				*errs = append(*errs, HTTPParamError{Source: source, Param: key, Field: fieldPath, Err: ErrHTTPParamMissing})
+			}
+
+			continue
+		}
+
+		err := httpSetValues(fv, values, sf.Tag.Get(EnvTagSep), sf.Tag.Get(HTTPTagFormat))
+
+		var unsupported envUnsupportedError
+
+		if errors.As(err, &unsupported) {
+			return fmt.Errorf("handy: HTTPBind() field %s: %w", fieldPath, err)
+		}
+
+		if err != nil {
+			*errs = append(*errs, HTTPParamError{Source: source, Param: key, Field: fieldPath, Err: err})
+		}
+	}
+
+	return nil
+}
+
+// httpBindNested visits a field without tags, when it's a struct or pointer to struct
+func httpBindNested(fv reflect.Value, path string, lookup func(source, key string) []string, errs *HTTPParamErrors) error {
+	t := fv.Type()
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || envIsScalar(t) {
+		return nil
+	}
+
+	if fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(t))
+		}
+
+		fv = fv.Elem()
+	}
+
+	return httpBindStruct(fv, path, lookup, errs)
+}
+
+// httpSetValues parses the values into fv, making a slice when fv is one
+func httpSetValues(fv reflect.Value, values []string, sep, format string) error {
+	t := fv.Type()
+
+	if t.Kind() != reflect.Slice {
+		return httpSetValue(fv, values[0], format)
+	}
+
+	var items []string
+
+	for _, v := range values {
+		if sep == "" {
+			items = append(items, v)
+			continue
+		}
+
+		for _, item := range strings.Split(v, sep) {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+
+	a := reflect.MakeSlice(t, len(items), len(items))
+
+	for i, item := range items {
+		if err := httpSetValue(a.Index(i), item, format); err != nil {
+			return err
+		}
+	}
+
+	fv.Set(a)
+
+	return nil
+}
+
+// httpSetValue parses s into fv, with the date formats and checkbox values of HTTPBind(), and the rules of EnvLoad() for the rest
+func httpSetValue(fv reflect.Value, s, format string) error {
+	t := fv.Type()
+
+	if t.Kind() == reflect.Ptr {
+		x := reflect.New(t.Elem())
+
+		if err := httpSetValue(x.Elem(), s, format); err != nil {
+			return err
+		}
+
+		fv.Set(x)
+
+		return nil
+	}
+
+	switch {
+	case t == timeType && format != "":
+		dt, err := DateTimeParse(s, format)
+
+		if err != nil {
+			return fmt.Errorf("%w: expected a date/time formatted as %s", ErrHTTPParamMalformed, format)
+		}
+
+		fv.Set(reflect.ValueOf(dt))
+
+		return nil
+	case t == dateType && format != "":
+		d, err := DateParse(s, format)
+
+		if err != nil {
+			return fmt.Errorf("%w: expected a date formatted as %s", ErrHTTPParamMalformed, format)
+		}
+
+		fv.Set(reflect.ValueOf(d))
+
+		return nil
+	case t.Kind() == reflect.Bool && strings.EqualFold(s, "on"):
+		fv.SetBool(true)
+
+		return nil
+	}
+
+	err := envSetValue(fv, s, ",")
+
+	var unsupported envUnsupportedError
+
+	if err == nil || errors.As(err, &unsupported) {
+		return err
+	}
+
+	return fmt.Errorf("%w: expected %s", ErrHTTPParamMalformed, httpExpected(t))
+}
+
+var dateType = reflect.TypeOf(Date{})
+
+// httpExpected describes the values accepted by a type, for error messages
+func httpExpected(t reflect.Type) string {
+	switch t {
+	case timeType:
+		return "a date/time formatted as RFC 3339"
+	case dateType:
+		return "a date formatted as yyyy-mm-dd"
+	case durationType:
+		return "a duration, like 1h30m"
+	case urlType:
+		return "a URL"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fmt.Sprintf("an integer between %d and %d", int64(-1)<<(t.Bits()-1), uint64(1)<<(t.Bits()-1)-1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprintf("a non-negative integer up to %s", strconv.FormatUint(^uint64(0)>>(64-t.Bits()), 10))
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	}
+
+	return "a valid " + t.String()
+}
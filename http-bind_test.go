@@ -0,0 +1,168 @@
+package handy
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPBind(t *testing.T) {
+	type filter struct {
+		Tags []string `query:"tag" sep:","`
+	}
+
+	type params struct {
+		ID        int64         `path:"id" required:"true"`
+		Page      uint16        `query:"page" default:"1"`
+		Limit     int8          `query:"limit" default:"20"`
+		Ratio     float32       `query:"ratio"`
+		Active    bool          `form:"active"`
+		Name      *string       `form:"name"`
+		Birth     time.Time     `form:"birth" format:"dd/mm/yyyy"`
+		Due       Date          `query:"due"`
+		Timeout   time.Duration `query:"timeout" default:"30s"`
+		IDs       []int         `query:"ids"`
+		RequestID string        `header:"X-Request-Id" query:"request_id"`
+		Filter    filter
+		Missing   *int `query:"missing"`
+		ignored   int
+	}
+
+	form := url.Values{"active": {"on"}, "name": {"Ana"}, "birth": {"31/12/1990"}}
+	query := "?page=3&ratio=0.5&due=2024-02-29&timeout=1m&ids=1&ids=2&ids=3&tag=a,b&tag=c&request_id=q-1"
+
+	r := httptest.NewRequest(http.MethodPost, "/users/9007199254740993"+query, strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Request-Id", "h-1")
+	r = HTTPWithPathParams(r, map[string]string{"id": "9007199254740993"})
+
+	var p params
+
+	if err := HTTPBind(r, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := params{
+		ID:        9007199254740993,
+		Page:      3,
+		Limit:     20,
+		Ratio:     0.5,
+		Active:    true,
+		Name:      p.Name,
+		Birth:     time.Date(1990, 12, 31, 0, 0, 0, 0, time.UTC),
+		Due:       NewDate(2024, time.February, 29),
+		Timeout:   time.Minute,
+		IDs:       []int{1, 2, 3},
+		RequestID: "q-1",
+		Filter:    filter{Tags: []string{"a", "b", "c"}},
+	}
+
+	if !reflect.DeepEqual(p, expected) || p.Name == nil || *p.Name != "Ana" {
+		t.Errorf("Test has failed!\n\tExpected: %+v, \n\tGot: %+v", expected, p)
+	}
+}
+
+func TestHTTPBindErrors(t *testing.T) {
+	var p struct {
+		ID    int       `path:"id" required:"true"`
+		Page  int8      `query:"page"`
+		Valid bool      `query:"valid"`
+		From  time.Time `query:"from" format:"yyyy-mm-dd"`
+		Sort  string    `query:"sort" required:"true" default:"name"`
+		Token string    `header:"X-Token" required:"true"`
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/?page=300&valid=maybe&from=31/12/2020", nil)
+
+	err := HTTPBind(r, &p)
+
+	var errs HTTPParamErrors
+
+	if !errors.As(err, &errs) || len(errs) != 5 {
+		t.Fatalf("Test has failed!\n\tExpected 5 errors, got %v", err)
+	}
+
+	tcs := []struct {
+		param    string
+		source   string
+		sentinel error
+		message  string
+	}{
+		{"id", HTTPTagPath, ErrHTTPParamMissing, "required parameter is missing"},
+		{"page", HTTPTagQuery, ErrHTTPParamMalformed, "malformed parameter: expected an integer between -128 and 127"},
+		{"valid", HTTPTagQuery, ErrHTTPParamMalformed, "malformed parameter: expected a boolean"},
+		{"from", HTTPTagQuery, ErrHTTPParamMalformed, "malformed parameter: expected a date/time formatted as yyyy-mm-dd"},
+		{"X-Token", HTTPTagHeader, ErrHTTPParamMissing, "required parameter is missing"},
+	}
+
+	messages := errs.Messages()
+
+	for _, tc := range tcs {
+		t.Run(tc.param, func(t *testing.T) {
+			e, ok := errs.Param(tc.param)
+
+			if !ok || e.Source != tc.source || !errors.Is(e, tc.sentinel) || messages[tc.param] != tc.message {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %v, %q", tc.message, e, messages[tc.param])
+			}
+		})
+	}
+
+	if p.Sort != "name" {
+		t.Errorf("Test has failed! The default should satisfy a required parameter, got %q", p.Sort)
+	}
+
+	var bad struct {
+		C chan int `query:"c"`
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/?c=1", nil)
+
+	if err := HTTPBind(r, &bad); err == nil || errors.As(err, &errs) {
+		t.Errorf("Test has failed!\n\tExpected a plain error for an unsupported type, got %v", err)
+	}
+
+	var badRequired struct {
+		Q string `query:"q" required:"yes"`
+	}
+
+	for _, target := range []string{"/?q=1", "/"} {
+		if err := HTTPBind(httptest.NewRequest(http.MethodGet, target, nil), &badRequired); err == nil || errors.As(err, &errs) {
+			t.Errorf("Test has failed!\n\tExpected a plain error for an invalid required tag, got %v", err)
+		}
+	}
+
+	var optional struct {
+		Q string `query:"q" required:"0"`
+	}
+
+	if err := HTTPBind(httptest.NewRequest(http.MethodGet, "/", nil), &optional); err != nil {
+		t.Errorf("Test has failed!\n\tExpected nil, got %v", err)
+	}
+}
+
+func TestHTTPBindMultipart(t *testing.T) {
+	var b bytes.Buffer
+
+	mw := multipart.NewWriter(&b)
+	_ = mw.WriteField("qty", "7")
+	_ = mw.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/?qty=1", &b)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	var p struct {
+		Qty      int `form:"qty"`
+		QueryQty int `query:"qty"`
+	}
+
+	if err := HTTPBind(r, &p); err != nil || p.Qty != 7 || p.QueryQty != 1 {
+		t.Errorf("Test has failed!\n\tGot: %+v, %v", p, err)
+	}
+}
@@ -0,0 +1,224 @@
+package handy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HTTPDecodeMaxBytes is the body size limit of HTTPDecodeJSON(), and of decoders without MaxBytes
+const HTTPDecodeMaxBytes = 1 << 20
+
+// Sentinels wrapped by HTTPDecodeError, to be tested with errors.Is()
+// HTTPProblemFromError() answers them as 400 Bad Request, but ErrHTTPBodyTooLarge as 413 and ErrHTTPUnsupportedMediaType as 415.
+var (
+	ErrHTTPBodyEmpty            = errors.New("handy: request body is empty")
+	ErrHTTPBodyTooLarge         = errors.New("handy: request body is too large")
+	ErrHTTPBodyMalformed        = errors.New("handy: malformed request body")
+	ErrHTTPUnsupportedMediaType = errors.New("handy: unsupported media type")
+)
+
+// HTTPDecodeError describes why a request body couldn't be decoded
+type HTTPDecodeError struct {
+	// Field is the path of the offending field, like "address.street", when known
+	Field string
+	// Offset is the position, in bytes, where the problem was found, when known
+	Offset int64
+	// Msg explains the problem, like "expected a number, got string"
+	Msg string
+	// Err wraps one of the ErrHTTPBody sentinels, or ErrHTTPUnsupportedMediaType
+	Err error
+}
+
+// Error returns the reason, the field and the position
+func (e HTTPDecodeError) Error() string {
+	s := e.Err.Error()
+
+	if e.Msg != "" {
+		s += ": " + e.Msg
+	}
+
+	if e.Field != "" {
+		s += fmt.Sprintf(` at field "%s"`, e.Field)
+	}
+
+	if e.Offset > 0 {
+		s += fmt.Sprintf(" (byte %d)", e.Offset)
+	}
+
+	return s
+}
+
+// Unwrap returns the reason, making errors.Is() work with the sentinels
+func (e HTTPDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPJSONDecoder reads JSON request bodies with limits and strict checks. The zero value is ready to use
+// Example: HTTPJSONDecoder{MaxBytes: 64 << 10, Validate: handy.Validate}.Decode(r, &order)
+type HTTPJSONDecoder struct {
+	// MaxBytes limits the body size. Zero means HTTPDecodeMaxBytes, and a negative value means no limit
+	MaxBytes int64
+	// AllowUnknownFields accepts object members without a matching struct field, that are rejected by default
+	AllowUnknownFields bool
+	// AllowAnyContentType skips the Content-Type check, that requires application/json or a +json type by default
+	AllowAnyContentType bool
+	// Validate, when set, is called with the decoded value, and its error is returned as it is
+	Validate func(v interface{}) error
+}
+
+// HTTPDecodeJSON decodes a JSON request body into v, with the defaults of HTTPJSONDecoder
+// The body must be a single JSON value, without trailing data, no larger than HTTPDecodeMaxBytes,
+// sent as application/json, and without unknown fields. Errors are HTTPDecodeError, ready for HTTPAnswerError().
+func HTTPDecodeJSON(r *http.Request, v interface{}) error {
+	return HTTPJSONDecoder{}.Decode(r, v)
+}
+
+// Decode reads the request body into v, according the decoder settings, and closes the body
+// v must be a non-nil pointer, otherwise a plain error is returned, instead of an HTTPDecodeError.
+func (d HTTPJSONDecoder) Decode(r *http.Request, v interface{}) error {
+	if r.Body != nil {
+		defer r.Body.Close()
+	}
+
+	// a programming error, that must not be answered to the client as a bad request
+	if rv := reflect.ValueOf(v); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("handy: HTTPJSONDecoder.Decode() requires a non-nil pointer, got %T", v)
+	}
+
+	if !d.AllowAnyContentType {
+		if err := httpCheckJSONContentType(r.Header.Get("Content-Type")); err != nil {
+			return err
+		}
+	}
+
+	if r.Body == nil || r.Body == http.NoBody {
+		return HTTPDecodeError{Err: ErrHTTPBodyEmpty}
+	}
+
+	maxBytes := d.MaxBytes
+
+	if maxBytes == 0 {
+		maxBytes = HTTPDecodeMaxBytes
+	}
+
+	body := &httpLimitedReader{r: r.Body, limit: maxBytes, remaining: maxBytes}
+
+	dec := json.NewDecoder(body)
+
+	if !d.AllowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(v); err != nil {
+		return httpDecodeError(err, body)
+	}
+
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		if body.exceeded || httpIsMaxBytesError(err) {
+			return httpDecodeError(err, body)
+		}
+
+		return HTTPDecodeError{Msg: "unexpected data after the JSON value", Err: ErrHTTPBodyMalformed}
+	}
+
+	if d.Validate != nil {
+		return d.Validate(v)
+	}
+
+	return nil
+}
+
+// httpCheckJSONContentType accepts application/json and types with the +json suffix, like application/merge-patch+json
+func httpCheckJSONContentType(contentType string) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return HTTPDecodeError{Msg: fmt.Sprintf("expected application/json, got %q", contentType), Err: ErrHTTPUnsupportedMediaType}
+	}
+
+	return nil
+}
+
+// httpDecodeError translates the errors of encoding/json into HTTPDecodeError
+func httpDecodeError(err error, body *httpLimitedReader) error {
+	if body.exceeded {
+		return HTTPDecodeError{Msg: fmt.Sprintf("limit is %d bytes", body.limit), Err: ErrHTTPBodyTooLarge}
+	}
+
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case httpIsMaxBytesError(err):
+		return HTTPDecodeError{Err: ErrHTTPBodyTooLarge}
+	case err == io.EOF:
+		return HTTPDecodeError{Err: ErrHTTPBodyEmpty}
+	case err == io.ErrUnexpectedEOF:
+		return HTTPDecodeError{Msg: "unexpected end of JSON", Offset: body.read, Err: ErrHTTPBodyMalformed}
+	case errors.As(err, &syntaxErr):
+		return HTTPDecodeError{Msg: strings.TrimPrefix(syntaxErr.Error(), "json: "), Offset: syntaxErr.Offset, Err: ErrHTTPBodyMalformed}
+	case errors.As(err, &typeErr):
+		msg := fmt.Sprintf("expected %s, got %s", httpExpected(typeErr.Type), typeErr.Value)
+		return HTTPDecodeError{Field: typeErr.Field, Msg: msg, Offset: typeErr.Offset, Err: ErrHTTPBodyMalformed}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return HTTPDecodeError{Field: field, Msg: "unknown field", Err: ErrHTTPBodyMalformed}
+	}
+
+	return HTTPDecodeError{Msg: strings.TrimPrefix(err.Error(), "json: "), Err: ErrHTTPBodyMalformed}
+}
+
+// httpIsMaxBytesError tells if the error comes from http.MaxBytesReader(), like in HTTPLimitBody()
+func httpIsMaxBytesError(err error) bool {
+	return err != nil && err.Error() == "http: request body too large"
+}
+
+// httpLimitedReader reads up to remaining bytes, then fails, recording that the limit was exceeded
+// A negative remaining means no limit.
+type httpLimitedReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	read      int64
+	exceeded  bool
+}
+
+func (l *httpLimitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		n, err := l.r.Read(p)
+		l.read += int64(n)
+
+		return n, err
+	}
+
+	if l.remaining == 0 {
+		// a byte more tells if the body has more data than allowed
+		var b [1]byte
+
+		if n, _ := l.r.Read(b[:]); n > 0 {
+			l.exceeded = true
+			return 0, ErrHTTPBodyTooLarge
+		}
+
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	l.remaining -= int64(n)
+
+	return n, err
+}
@@ -0,0 +1,125 @@
+package handy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type httpTestPayload struct {
+	Name    string `json:"name" handy:"required"`
+	Age     int8   `json:"age"`
+	Address struct {
+		Street string `json:"street"`
+		Number int    `json:"number"`
+	} `json:"address"`
+}
+
+func TestHTTPDecodeJSON(t *testing.T) {
+	tcs := []struct {
+		summary          string
+		contentType      string
+		body             string
+		expectedSentinel error
+		expectedField    string
+		expectedMessage  string
+	}{
+		{"valid", "application/json", `{"name":"Ana","age":30,"address":{"street":"Main","number":7}}` + "\n", nil, "", ""},
+		{"merge patch", "application/merge-patch+json; charset=utf-8", `{"name":"Ana"}`, nil, "", ""},
+		{"content type", "text/plain", `{"name":"Ana"}`, ErrHTTPUnsupportedMediaType, "", `expected application/json, got "text/plain"`},
+		{"empty", "application/json", "", ErrHTTPBodyEmpty, "", ""},
+		{"syntax", "application/json", `{"name":"Ana",}`, ErrHTTPBodyMalformed, "", "invalid character '}' looking for beginning of object key string"},
+		{"truncated", "application/json", `{"name":"Ana"`, ErrHTTPBodyMalformed, "", "unexpected end of JSON"},
+		{"type", "application/json", `{"age":"thirty"}`, ErrHTTPBodyMalformed, "age", "expected an integer between -128 and 127, got string"},
+		{"overflow", "application/json", `{"age":300}`, ErrHTTPBodyMalformed, "age", "expected an integer between -128 and 127, got number 300"},
+		{"nested type", "application/json", `{"address":{"number":"7"}}`, ErrHTTPBodyMalformed, "address.number", "expected an integer between -9223372036854775808 and 9223372036854775807, got string"},
+		{"unknown field", "application/json", `{"name":"Ana","nickname":"A"}`, ErrHTTPBodyMalformed, "nickname", "unknown field"},
+		{"trailing", "application/json", `{"name":"Ana"} {"name":"Bob"}`, ErrHTTPBodyMalformed, "", "unexpected data after the JSON value"},
+		{"too large", "application/json", `{"name":"` + strings.Repeat("a", HTTPDecodeMaxBytes) + `"}`, ErrHTTPBodyTooLarge, "", "limit is 1048576 bytes"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", tc.contentType)
+
+			var p httpTestPayload
+
+			err := HTTPDecodeJSON(r, &p)
+
+			if tc.expectedSentinel == nil {
+				if err != nil || p.Name != "Ana" {
+					t.Errorf("Test has failed!\n\tGot: %+v, %v", p, err)
+				}
+
+				return
+			}
+
+			var de HTTPDecodeError
+
+			if !errors.As(err, &de) || !errors.Is(err, tc.expectedSentinel) || de.Field != tc.expectedField || de.Msg != tc.expectedMessage {
+				t.Errorf("Test has failed!\n\tExpected: %v %q %q, \n\tGot: %#v", tc.expectedSentinel, tc.expectedField, tc.expectedMessage, err)
+			}
+		})
+	}
+}
+
+func TestHTTPJSONDecoder(t *testing.T) {
+	d := HTTPJSONDecoder{MaxBytes: 16, AllowUnknownFields: true, AllowAnyContentType: true, Validate: Validate}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"nickname":"A"}`))
+
+	var p httpTestPayload
+
+	err := d.Decode(r, &p)
+
+	var ve ValidationErrors
+
+	if !errors.As(err, &ve) || len(ve) != 1 || ve[0].Field != "name" {
+		t.Errorf("Test has failed!\n\tExpected a validation error for name, got %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"nickname":"AB"}`))
+
+	if err := d.Decode(r, &p); !errors.Is(err, ErrHTTPBodyTooLarge) {
+		t.Errorf("Test has failed!\n\tExpected ErrHTTPBodyTooLarge, got %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"nickname":"AB"}`))
+
+	_ = HTTPAnswerError(rec, r, d.Decode(r, &p))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Test has failed!\n\tExpected: 413, \n\tGot: %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"age":"x"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	_ = HTTPAnswerError(rec, r, HTTPDecodeJSON(r, &p))
+
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"invalid-params":[{"name":"age"`) {
+		t.Errorf("Test has failed!\n\tGot: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHTTPDecodeJSONTarget(t *testing.T) {
+	var p httpTestPayload
+
+	for _, v := range []interface{}{nil, p, (*httpTestPayload)(nil)} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Ana"}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		err := HTTPDecodeJSON(r, v)
+
+		var de HTTPDecodeError
+
+		if err == nil || errors.As(err, &de) || HTTPProblemFromError(err).Status != http.StatusInternalServerError {
+			t.Errorf("Test has failed!\n\tExpected a plain error for %T, got %#v", v, err)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package handy
+
+import (
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTTPEncodeFunc writes v to w, in the format of an encoder registered with HTTPEncoderRegister()
+// Encoders should write as they go, instead of buffering the whole body. See HTTPEach()
+type HTTPEncodeFunc func(w io.Writer, v interface{}) error
+
+// HTTPTagCSV is the struct tag read by the CSV encoder to name the columns. "-" skips the field
+const HTTPTagCSV = "csv"
+
+// ErrHTTPUnsupportedData is returned by encoders given data they can't write, like a map to the CSV encoder
+var ErrHTTPUnsupportedData = errors.New("handy: data not supported by the encoder")
+
+type httpEncoder struct {
+	contentType string
+	encode      HTTPEncodeFunc
+}
+
+var (
+	httpEncoders = []httpEncoder{
+		{"application/json; charset=utf-8", httpEncodeJSON},
+		{"application/xml; charset=utf-8", httpEncodeXML},
+		{"text/csv; charset=utf-8", httpEncodeCSV},
+		{"text/plain; charset=utf-8", httpEncodeText},
+	}
+	httpEncodersMu sync.RWMutex
+)
+
+// HTTPEncoderRegister adds an encoder to be chosen by HTTPAnswer(), or replaces the one of the same media type
+// New encoders are the least preferred when the client accepts more than one type with the same q-value.
+// Example: HTTPEncoderRegister("application/msgpack", func(w io.Writer, v interface{}) error { return msgpack.NewEncoder(w).Encode(v) })
+func HTTPEncoderRegister(contentType string, encode HTTPEncodeFunc) {
+	httpEncodersMu.Lock()
+	defer httpEncodersMu.Unlock()
+
+	mediaType := httpMediaType(contentType)
+
+	for i, e := range httpEncoders {
+		if httpMediaType(e.contentType) == mediaType {
+			httpEncoders[i] = httpEncoder{contentType, encode}
+			return
+		}
+	}
+
+	httpEncoders = append(httpEncoders, httpEncoder{contentType, encode})
+}
+
+func httpEncodersSnapshot() []httpEncoder {
+	httpEncodersMu.RLock()
+	defer httpEncodersMu.RUnlock()
+
+	return append([]httpEncoder{}, httpEncoders...)
+}
+
+// httpMediaType returns the lowercased media type of a content type, without parameters
+func httpMediaType(contentType string) string {
+	if mt, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mt
+	}
+
+	return strings.ToLower(contentType)
+}
+
+// HTTPEach calls fn with every item of a slice, array or receive channel, returning false when v is none of them
+// Channels are read until closed. It's meant for encoders streaming lists.
+func HTTPEach(v interface{}, fn func(item interface{}) error) (bool, error) {
+	if !httpIsList(v) {
+		return false, nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() != reflect.Chan {
+		for i := 0; i < rv.Len(); i++ {
+			if err := fn(rv.Index(i).Interface()); err != nil {
+				return true, err
+			}
+		}
+
+		return true, nil
+	}
+
+	for {
+		item, ok := rv.Recv()
+
+		if !ok {
+			return true, nil
+		}
+
+		if err := fn(item.Interface()); err != nil {
+			return true, err
+		}
+	}
+}
+
+// httpIsList tells if v is a slice, array or receive channel. []byte is a value, not a list
+func httpIsList(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return rv.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Chan:
+		return rv.Type().ChanDir()&reflect.RecvDir != 0
+	}
+
+	return false
+}
+
+// httpEncodeJSON writes lists as a JSON array, one item at a time, and other values with encoding/json
+func httpEncodeJSON(w io.Writer, v interface{}) error {
+	if !httpIsList(v) {
+		return json.NewEncoder(w).Encode(v)
+	}
+
+	sep := "["
+
+	_, err := HTTPEach(v, func(item interface{}) error {
+		b, err := json.Marshal(item)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+
+		sep = ","
+		_, err = w.Write(b)
+
+		return err
+	})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, Tif(sep == "[", "[]\n", "]\n").(string))
+
+	return err
+}
+
+// httpEncodeXML writes lists inside an <items> element, one item at a time, and other values with encoding/xml
+func httpEncodeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	e := xml.NewEncoder(w)
+
+	if !httpIsList(v) {
+		return e.Encode(v)
+	}
+
+	items := xml.StartElement{Name: xml.Name{Local: "items"}}
+
+	if err := e.EncodeToken(items); err != nil {
+		return err
+	}
+
+	if _, err := HTTPEach(v, func(item interface{}) error { return e.Encode(item) }); err != nil {
+		return err
+	}
+
+	if err := e.EncodeToken(items.End()); err != nil {
+		return err
+	}
+
+	return e.Flush()
+}
+
+// httpEncodeCSV writes a header with the field names and a row per item
+// It accepts a list of structs or pointers to structs, or a list of []string rows, without header. A single struct is a one row list.
+// The columns come from the first struct, so every item in the list must be of the same type, and rows can't be mixed with structs.
+func httpEncodeCSV(w io.Writer, v interface{}) error {
+	cw := csv.NewWriter(w)
+
+	var (
+		columns []int
+		rowType reflect.Type
+	)
+
+	write := func(item interface{}) error {
+		rv := reflect.ValueOf(item)
+
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+
+		row, isRow := item.([]string)
+
+		if !isRow && rv.Kind() != reflect.Struct {
+			return fmt.Errorf("%w: CSV requires structs or []string rows, got %T", ErrHTTPUnsupportedData, item)
+		}
+
+		// []string rows and structs can't be mixed either, as structs write a header
+		if rowType != nil && rv.Type() != rowType {
+			return fmt.Errorf("%w: CSV requires items of the same type, got %s after %s", ErrHTTPUnsupportedData, rv.Type(), rowType)
+		}
+
+		if isRow {
+			rowType = rv.Type()
+
+			return cw.Write(row)
+		}
+
+		if rowType == nil {
+			header := []string{}
+			rowType = rv.Type()
+
+			for i := 0; i < rv.NumField(); i++ {
+				sf := rv.Type().Field(i)
+				name := sf.Tag.Get(HTTPTagCSV)
+
+				if sf.PkgPath != "" || name == "-" {
+					continue
+				}
+
+				columns = append(columns, i)
+				header = append(header, Tif(name == "", sf.Name, name).(string))
+			}
+
+			if err := cw.Write(header); err != nil {
+				return err
+			}
+		}
+
+		row = make([]string, len(columns))
+
+		for i, c := range columns {
+			row[i] = httpFormatCell(rv.Field(c))
+		}
+
+		return cw.Write(row)
+	}
+
+	isList, err := HTTPEach(v, write)
+
+	if !isList {
+		err = write(v)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// httpFormatCell writes a CSV cell: nil pointers are empty, times are RFC 3339, and TextMarshalers write their text
+func httpFormatCell(fv reflect.Value) string {
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return ""
+		}
+
+		fv = fv.Elem()
+	}
+
+	switch x := fv.Interface().(type) {
+	case time.Time:
+		return x.Format(time.RFC3339)
+	case encoding.TextMarshaler:
+		if b, err := x.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(fv.Interface())
+}
+
+// httpEncodeText writes lists one item per line, and other values as fmt.Print does
+func httpEncodeText(w io.Writer, v interface{}) error {
+	isList, err := HTTPEach(v, func(item interface{}) error {
+		_, err := fmt.Fprintln(w, item)
+
+		return err
+	})
+
+	if !isList {
+		_, err = fmt.Fprint(w, v)
+	}
+
+	return err
+}
@@ -0,0 +1,498 @@
+package handy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HTTPRequestIDHeader is the header read and written by HTTPRequestID()
+const HTTPRequestIDHeader = "X-Request-Id"
+
+// httpRequestIDMaxLength limits the incoming request IDs that are trusted
+const httpRequestIDMaxLength = 128
+
+type httpRequestIDKey struct{}
+
+// HTTPChain composes middlewares, so HTTPChain(a, b, c)(h) is a(b(c(h))): the first one sees the request first
+func HTTPChain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+
+		return next
+	}
+}
+
+// HTTPRequestID gives every request an ID, kept in the context and echoed in the X-Request-Id response header
+// An incoming X-Request-Id is propagated when it's made of up to 128 visible ASCII characters; otherwise a random one is made.
+// Problems answered by HTTPAnswerError() carry the ID as the "request_id" member.
+func HTTPRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(HTTPRequestIDHeader)
+
+		if !httpValidRequestID(id) {
+			id = httpNewRequestID()
+		}
+
+		w.Header().Set(HTTPRequestIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), httpRequestIDKey{}, id)))
+	})
+}
+
+// HTTPRequestIDFrom returns the request ID set by HTTPRequestID(), or "" if there's none
+func HTTPRequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(httpRequestIDKey{}).(string)
+
+	return id
+}
+
+func httpValidRequestID(id string) bool {
+	if id == "" || len(id) > httpRequestIDMaxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func httpNewRequestID() string {
+	b := make([]byte, 16)
+
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// HTTPRecover answers 500 Internal Server Error as problem details when the handler panics, logging the panic and the stack
+// http.ErrAbortHandler panics are let through, as they're meant to abort the response. When the handler already started
+// answering, there's nothing to be done but logging.
+func HTTPRecover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := httpWrapResponseWriter(w)
+
+		defer func() {
+			p := recover()
+
+			if p == nil {
+				return
+			}
+
+			if p == http.ErrAbortHandler {
+				panic(p)
+			}
+
+			log.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, p, debug.Stack())
+
+			if !rw.wroteHeader {
+				_ = HTTPAnswerError(rw, r, HTTPProblem{Status: http.StatusInternalServerError})
+			}
+		}()
+
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// HTTPAccessEntry describes an answered request, for HTTPAccessLog()
+type HTTPAccessEntry struct {
+	Time      time.Time
+	Method    string
+	Path      string
+	Status    int
+	Bytes     int64
+	Duration  time.Duration
+	RequestID string
+	Remote    string
+	UserAgent string
+}
+
+// String returns the entry as key=value pairs, quoting values when needed
+// Example: method=GET path=/orders status=200 bytes=512 duration=1.2ms request_id=4f2a remote=10.0.0.7:51234 user_agent="curl/8.0"
+func (e HTTPAccessEntry) String() string {
+	pairs := []struct {
+		key   string
+		value string
+	}{
+		{"time", e.Time.Format(time.RFC3339)},
+		{"method", e.Method},
+		{"path", e.Path},
+		{"status", strconv.Itoa(e.Status)},
+		{"bytes", strconv.FormatInt(e.Bytes, 10)},
+		{"duration", e.Duration.String()},
+		{"request_id", e.RequestID},
+		{"remote", e.Remote},
+		{"user_agent", e.UserAgent},
+	}
+
+	var b strings.Builder
+
+	for i, p := range pairs {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteString(p.key)
+		b.WriteByte('=')
+
+		if p.value == "" || strings.ContainsAny(p.value, " \"=\\") || strings.IndexFunc(p.value, func(r rune) bool { return r < ' ' }) >= 0 {
+			b.WriteString(strconv.Quote(p.value))
+		} else {
+			b.WriteString(p.value)
+		}
+	}
+
+	return b.String()
+}
+
+// HTTPAccessLog calls write with an entry for every answered request. A nil write logs the entry with the log package
+// Place it after HTTPRequestID() to have the request IDs, and before HTTPRecover() to log the 500 answers of panics.
+// Example: handy.HTTPAccessLog(func(e handy.HTTPAccessEntry) { logger.Info("request", "status", e.Status, "path", e.Path) })
+func HTTPAccessLog(write func(e HTTPAccessEntry)) func(http.Handler) http.Handler {
+	if write == nil {
+		write = func(e HTTPAccessEntry) { log.Println(e) }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := httpWrapResponseWriter(w)
+
+			next.ServeHTTP(rw, r)
+
+			write(HTTPAccessEntry{
+				Time:      start,
+				Method:    r.Method,
+				Path:      r.URL.Path,
+				Status:    Tif(rw.status == 0, http.StatusOK, rw.status).(int),
+				Bytes:     rw.bytes,
+				Duration:  time.Since(start),
+				RequestID: HTTPRequestIDFrom(r.Context()),
+				Remote:    r.RemoteAddr,
+				UserAgent: r.UserAgent(),
+			})
+		})
+	}
+}
+
+// HTTPCORSOptions tells which cross-origin requests HTTPCORS() allows
+type HTTPCORSOptions struct {
+	// AllowedOrigins are the allowed origins, like "https://example.com". "*" allows any origin
+	AllowedOrigins []string
+	// AllowedMethods are answered to preflight requests. When empty, GET, HEAD and POST are allowed
+	AllowedMethods []string
+	// AllowedHeaders are answered to preflight requests. When empty, the requested headers are allowed
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers the browser lets scripts read
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies. The request origin is echoed then, as "*" isn't accepted with credentials
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache preflight answers. Zero leaves it to the browser
+	MaxAge time.Duration
+}
+
+// HTTPCORS answers preflight requests and adds the CORS headers to the answers of allowed origins
+// Requests from other origins pass without CORS headers, so browsers block them.
+func HTTPCORS(opts HTTPCORSOptions) func(http.Handler) http.Handler {
+	methods := opts.AllowedMethods
+
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodPost}
+	}
+
+	allowed := func(origin string) bool {
+		for _, o := range opts.AllowedOrigins {
+			if o == "*" || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	anyOrigin := false
+
+	for _, o := range opts.AllowedOrigins {
+		anyOrigin = anyOrigin || o == "*"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			w.Header().Add("Vary", "Origin")
+
+			if origin == "" || !allowed(origin) {
+				if preflight {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+
+			if anyOrigin && !opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if len(opts.ExposedHeaders) > 0 {
+					h.Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+			if len(opts.AllowedHeaders) > 0 {
+				h.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+			} else if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+				h.Set("Access-Control-Allow-Headers", requested)
+			}
+
+			if opts.MaxAge > 0 {
+				h.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// HTTPLimitBody limits request bodies to maxBytes
+// Requests declaring a larger Content-Length are answered 413 Request Entity Too Large right away. Larger bodies without
+// Content-Length fail while read, and HTTPDecodeJSON() reports them with ErrHTTPBodyTooLarge.
+func HTTPLimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				_ = HTTPAnswerError(w, r, HTTPDecodeError{Msg: fmt.Sprintf("limit is %d bytes", maxBytes), Err: ErrHTTPBodyTooLarge})
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// HTTPTimeout answers 503 Service Unavailable as problem details when the handler takes longer than d
+// The request context is canceled at the timeout, so handlers can stop their work. Like http.TimeoutHandler(),
+// the answer is buffered until the handler returns, and later writes fail with http.ErrHandlerTimeout.
+// Panics of the handler are raised again in the calling goroutine, to be caught by HTTPRecover().
+func HTTPTimeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			r = r.WithContext(ctx)
+			tw := &httpTimeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+
+				next.ServeHTTP(tw, r)
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				for k, v := range tw.header {
+					w.Header()[k] = v
+				}
+
+				w.WriteHeader(Tif(tw.status == 0, http.StatusOK, tw.status).(int))
+				_, _ = w.Write(tw.buf.Bytes())
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				tw.timedOut = true
+
+				if ctx.Err() == context.DeadlineExceeded {
+					_ = HTTPAnswerError(w, r, HTTPProblem{Status: http.StatusServiceUnavailable, Detail: "request timed out"})
+				}
+			}
+		})
+	}
+}
+
+// httpTimeoutWriter buffers the answer of a handler run by HTTPTimeout()
+type httpTimeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	timedOut bool
+}
+
+func (tw *httpTimeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *httpTimeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+
+	return tw.buf.Write(p)
+}
+
+func (tw *httpTimeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+
+	tw.status = status
+}
+
+// httpResponseWriter records the status and size of an answer, for HTTPRecover() and HTTPAccessLog()
+type httpResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// httpWrapResponseWriter wraps w, unless it's already wrapped, so chained middlewares share the records
+func httpWrapResponseWriter(w http.ResponseWriter) *httpResponseWriter {
+	if rw, ok := w.(*httpResponseWriter); ok {
+		return rw
+	}
+
+	return &httpResponseWriter{ResponseWriter: w}
+}
+
+func (rw *httpResponseWriter) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *httpResponseWriter) Write(p []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+
+	return n, err
+}
+
+// Flush lets streaming answers, like the lists of HTTPAnswer(), go through the middlewares
+func (rw *httpResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		if !rw.wroteHeader {
+			rw.WriteHeader(http.StatusOK)
+		}
+
+		f.Flush()
+	}
+}
+
+// Hijack lets handlers take over the connection, like websockets do, when the original writer allows it
+func (rw *httpResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+
+	if !ok {
+		return nil, nil, fmt.Errorf("handy: %T doesn't support hijacking", rw.ResponseWriter)
+	}
+
+	return h.Hijack()
+}
+
+// Push starts an HTTP/2 server push when the original writer allows it, or returns http.ErrNotSupported
+func (rw *httpResponseWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := rw.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+
+	return http.ErrNotSupported
+}
+
+// ReadFrom keeps the sendfile optimization of the original writer, used by io.Copy() and http.ServeContent()
+func (rw *httpResponseWriter) ReadFrom(r io.Reader) (int64, error) {
+	rf, ok := rw.ResponseWriter.(io.ReaderFrom)
+
+	if !ok {
+		// hiding ReadFrom, so io.Copy() doesn't call it again
+		return io.Copy(struct{ io.Writer }{rw}, r)
+	}
+
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+
+	n, err := rf.ReadFrom(r)
+	rw.bytes += n
+
+	return n, err
+}
+
+// Unwrap returns the original writer, for http.ResponseController, from Go 1.20 on
+func (rw *httpResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,282 @@
+package handy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPRequestID(t *testing.T) {
+	var seen string
+
+	h := HTTPRequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = HTTPRequestIDFrom(r.Context())
+	}))
+
+	tcs := []struct {
+		incoming   string
+		propagated bool
+	}{
+		{"abc-123", true},
+		{"", false},
+		{"has space", false},
+		{strings.Repeat("x", 129), false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.incoming, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set(HTTPRequestIDHeader, tc.incoming)
+
+			h.ServeHTTP(rec, r)
+
+			if seen == "" || rec.Header().Get(HTTPRequestIDHeader) != seen || (seen == tc.incoming) != tc.propagated {
+				t.Errorf("Test has failed!\n\tGot: %q, header %q", seen, rec.Header().Get(HTTPRequestIDHeader))
+			}
+		})
+	}
+}
+
+func TestHTTPRecover(t *testing.T) {
+	var logs bytes.Buffer
+
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	var entry HTTPAccessEntry
+
+	h := HTTPChain(HTTPRequestID, HTTPAccessLog(func(e HTTPAccessEntry) { entry = e }), HTTPRecover)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.Header.Set(HTTPRequestIDHeader, "req-1")
+
+	h.ServeHTTP(rec, r)
+
+	var p HTTPProblem
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Code != http.StatusInternalServerError || p.Status != 500 || p.Instance != "/orders" || p.Extensions["request_id"] != "req-1" {
+		t.Errorf("Test has failed!\n\tGot: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if !strings.Contains(logs.String(), "panic serving GET /orders: boom") {
+		t.Errorf("Test has failed! The panic should be logged, got %s", logs.String())
+	}
+
+	if entry.Status != 500 || entry.RequestID != "req-1" || entry.Path != "/orders" || entry.Bytes != int64(rec.Body.Len()) {
+		t.Errorf("Test has failed!\n\tGot: %s", entry)
+	}
+}
+
+func TestHTTPAccessEntry(t *testing.T) {
+	e := HTTPAccessEntry{
+		Time:      time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC),
+		Method:    "GET",
+		Path:      "/orders",
+		Status:    200,
+		Bytes:     512,
+		Duration:  1500 * time.Microsecond,
+		Remote:    "10.0.0.7:51234",
+		UserAgent: "curl/8.0 (x86_64)",
+	}
+
+	expected := `time=2024-03-01T10:00:00Z method=GET path=/orders status=200 bytes=512 duration=1.5ms request_id="" remote=10.0.0.7:51234 user_agent="curl/8.0 (x86_64)"`
+
+	if e.String() != expected {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", expected, e.String())
+	}
+}
+
+func TestHTTPCORS(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	tcs := []struct {
+		summary        string
+		opts           HTTPCORSOptions
+		method         string
+		origin         string
+		expectedStatus int
+		expectedOrigin string
+		expectedMethod string
+	}{
+		{"allowed", HTTPCORSOptions{AllowedOrigins: []string{"https://a.com"}}, http.MethodGet, "https://a.com", 200, "https://a.com", ""},
+		{"denied", HTTPCORSOptions{AllowedOrigins: []string{"https://a.com"}}, http.MethodGet, "https://b.com", 200, "", ""},
+		{"any", HTTPCORSOptions{AllowedOrigins: []string{"*"}}, http.MethodGet, "https://b.com", 200, "*", ""},
+		{"any with credentials", HTTPCORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true}, http.MethodGet, "https://b.com", 200, "https://b.com", ""},
+		{"preflight", HTTPCORSOptions{AllowedOrigins: []string{"https://a.com"}, AllowedMethods: []string{"GET", "PUT"}}, http.MethodOptions, "https://a.com", 204, "https://a.com", "GET, PUT"},
+		{"denied preflight", HTTPCORSOptions{AllowedOrigins: []string{"https://a.com"}}, http.MethodOptions, "https://b.com", 204, "", ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, "/", nil)
+			r.Header.Set("Origin", tc.origin)
+
+			if tc.method == http.MethodOptions {
+				r.Header.Set("Access-Control-Request-Method", "PUT")
+			}
+
+			HTTPCORS(tc.opts)(ok).ServeHTTP(rec, r)
+
+			if rec.Code != tc.expectedStatus || rec.Header().Get("Access-Control-Allow-Origin") != tc.expectedOrigin || rec.Header().Get("Access-Control-Allow-Methods") != tc.expectedMethod {
+				t.Errorf("Test has failed!\n\tExpected: %d %q %q, \n\tGot: %d %v", tc.expectedStatus, tc.expectedOrigin, tc.expectedMethod, rec.Code, rec.Header())
+			}
+		})
+	}
+}
+
+func TestHTTPLimitBody(t *testing.T) {
+	h := HTTPLimitBody(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v map[string]string
+
+		if err := HTTPDecodeJSON(r, &v); err != nil {
+			_ = HTTPAnswerError(w, r, err)
+			return
+		}
+
+		_ = HTTPAnswerJSON(w, v)
+	}))
+
+	tcs := []struct {
+		summary        string
+		body           string
+		chunked        bool
+		expectedStatus int
+	}{
+		{"small", `{"a":"b"}`, false, http.StatusOK},
+		{"declared large", `{"a":"bbbbbbbbbbbbbbbbbbbb"}`, false, http.StatusRequestEntityTooLarge},
+		{"chunked large", `{"a":"bbbbbbbbbbbbbbbbbbbb"}`, true, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", "application/json")
+
+			if tc.chunked {
+				r.ContentLength = -1
+			}
+
+			h.ServeHTTP(rec, r)
+
+			if rec.Code != tc.expectedStatus {
+				t.Errorf("Test has failed!\n\tExpected: %d, \n\tGot: %d %s", tc.expectedStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPTimeout(t *testing.T) {
+	h := HTTPTimeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Duration(HTTPRequestAsInteger(r, "sleep")) * time.Millisecond):
+			w.Header().Set("X-Slept", "yes")
+			_ = HTTPAnswerJSONStatus(w, http.StatusAccepted, map[string]bool{"ok": true})
+		case <-r.Context().Done():
+		}
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?sleep=1", nil))
+
+	if rec.Code != http.StatusAccepted || rec.Header().Get("X-Slept") != "yes" || rec.Body.String() != `{"ok":true}` {
+		t.Errorf("Test has failed!\n\tGot: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?sleep=1000", nil))
+
+	if rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Content-Type") != HTTPProblemContentType {
+		t.Errorf("Test has failed!\n\tGot: %d %s", rec.Code, rec.Body.String())
+	}
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("Test has failed! The panic should be raised again, got %v", p)
+		}
+	}()
+
+	HTTPTimeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestHTTPResponseWriterHijack(t *testing.T) {
+	entries := make(chan HTTPAccessEntry, 2)
+
+	srv := httptest.NewServer(HTTPChain(HTTPAccessLog(func(e HTTPAccessEntry) { entries <- e }), HTTPRecover)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/copy" {
+			_, _ = io.Copy(w, strings.NewReader("copied"))
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+
+		if err != nil {
+			t.Errorf("Test has failed! Hijack() should reach the server connection, got %v", err)
+			return
+		}
+
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 8\r\nConnection: close\r\n\r\nhijacked")
+		_ = buf.Flush()
+	})))
+	defer srv.Close()
+
+	tcs := []struct {
+		path     string
+		expected string
+	}{
+		{"/", "hijacked"},
+		{"/copy", "copied"},
+	}
+
+	for _, tc := range tcs {
+		resp, err := http.Get(srv.URL + tc.path)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		if string(body) != tc.expected {
+			t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", tc.expected, body)
+		}
+	}
+
+	<-entries
+
+	if entry := <-entries; entry.Bytes != int64(len("copied")) {
+		t.Errorf("Test has failed! ReadFrom() should count the bytes, got %d", entry.Bytes)
+	}
+
+	rw := httpWrapResponseWriter(httptest.NewRecorder())
+
+	if _, _, err := rw.Hijack(); err == nil {
+		t.Error("Test has failed! Hijack() should fail when the original writer can't hijack")
+	}
+
+	if err := rw.Push("/style.css", nil); err != http.ErrNotSupported {
+		t.Errorf("Test has failed!\n\tExpected http.ErrNotSupported, got %v", err)
+	}
+}
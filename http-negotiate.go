@@ -0,0 +1,239 @@
+package handy
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// httpAcceptRange is an item of an Accept or Accept-Encoding header
+type httpAcceptRange struct {
+	value string
+	q     float64
+}
+
+// httpParseAccept reads the items of an Accept like header, lowercased, with their q-values
+// Items with malformed q-values are taken as q=0, as they can't be trusted to be wanted
+func httpParseAccept(header string) []httpAcceptRange {
+	var a []httpAcceptRange
+
+	for _, item := range strings.Split(header, ",") {
+		parts := strings.Split(item, ";")
+		value := strings.ToLower(strings.TrimSpace(parts[0]))
+
+		if value == "" {
+			continue
+		}
+
+		ar := httpAcceptRange{value: value, q: 1}
+
+		for _, param := range parts[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+
+			if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != "q" {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+
+			ar.q = q
+		}
+
+		a = append(a, ar)
+	}
+
+	return a
+}
+
+// httpMediaMatch returns how specifically the accepted range matches the media type: 3 for type/subtype,
+// 2 for type/*, 1 for */*, and 0 when it doesn't match
+func httpMediaMatch(accepted, mediaType string) int {
+	switch {
+	case accepted == mediaType:
+		return 3
+	case accepted == "*/*":
+		return 1
+	case strings.HasSuffix(accepted, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(accepted, "*")):
+		return 2
+	}
+
+	return 0
+}
+
+// HTTPNegotiate returns the offered media type that best fits the request Accept header, or "" if none is acceptable
+// The q-value of each offer comes from its most specific matching range, and the highest wins.
+// Ties are broken by the offers order, so the first offer is the preferred one, and the answer when there's no Accept header.
+// Example: HTTPNegotiate(r, "application/json", "text/csv")
+func HTTPNegotiate(r *http.Request, offers ...string) string {
+	header := r.Header.Get("Accept")
+
+	if strings.TrimSpace(header) == "" {
+		if len(offers) > 0 {
+			return offers[0]
+		}
+
+		return ""
+	}
+
+	ranges := httpParseAccept(header)
+	best, bestQ := "", 0.0
+
+	for _, offer := range offers {
+		mediaType := strings.ToLower(offer)
+
+		if mt, _, err := mime.ParseMediaType(offer); err == nil {
+			mediaType = mt
+		}
+
+		q, specificity := 0.0, 0
+
+		for _, ar := range ranges {
+			if s := httpMediaMatch(ar.value, mediaType); s > specificity {
+				q, specificity = ar.q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// httpContentEncodings are the compressions HTTPAnswerCompressed() uses, by preference
+var httpContentEncodings = []string{"gzip", "deflate"}
+
+// httpNegotiateEncoding returns the compression to be used according an Accept-Encoding header, or "" for none
+func httpNegotiateEncoding(header string) string {
+	ranges := httpParseAccept(header)
+	wildcard := -1.0
+
+	for _, ar := range ranges {
+		if ar.value == "*" {
+			wildcard = ar.q
+		}
+	}
+
+	best, bestQ := "", 0.0
+
+	for _, encoding := range httpContentEncodings {
+		q := wildcard
+		found := false
+
+		for _, ar := range ranges {
+			if ar.value == encoding {
+				q, found = ar.q, true
+				break
+			}
+		}
+
+		if !found && wildcard < 0 {
+			continue
+		}
+
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+
+	return best
+}
+
+// HTTPAnswer writes data with the status, encoded by the registered encoder that best fits the request Accept header
+// JSON, XML, CSV and plain text are registered by default, and JSON is the answer when the client accepts anything.
+// When no encoder is acceptable, it answers 406 Not Acceptable as problem details. See HTTPEncoderRegister()
+// Slices, arrays and receive channels are streamed item by item, so large lists aren't buffered. As the status is already
+// written then, an error while encoding leaves the response truncated, and is returned to be logged.
+func HTTPAnswer(w http.ResponseWriter, r *http.Request, status int, data interface{}) error {
+	return httpAnswerNegotiated(w, r, status, data, false)
+}
+
+// HTTPAnswerCompressed works like HTTPAnswer(), compressing the body with gzip or deflate when the client accepts them
+func HTTPAnswerCompressed(w http.ResponseWriter, r *http.Request, status int, data interface{}) error {
+	return httpAnswerNegotiated(w, r, status, data, true)
+}
+
+func httpAnswerNegotiated(w http.ResponseWriter, r *http.Request, status int, data interface{}, compress bool) error {
+	encoders := httpEncodersSnapshot()
+	offers := make([]string, len(encoders))
+
+	for i, e := range encoders {
+		offers[i] = e.contentType
+	}
+
+	w.Header().Add("Vary", "Accept")
+
+	chosen := HTTPNegotiate(r, offers...)
+
+	if chosen == "" {
+		return HTTPAnswerProblem(w, HTTPProblem{
+			Status:     http.StatusNotAcceptable,
+			Detail:     "acceptable media types: " + strings.Join(offers, ", "),
+			Extensions: map[string]interface{}{"acceptable": offers},
+		})
+	}
+
+	var encode HTTPEncodeFunc
+
+	for _, e := range encoders {
+		if e.contentType == chosen {
+			encode = e.encode
+		}
+	}
+
+	w.Header().Set("Content-Type", chosen)
+
+	var cw io.WriteCloser
+
+	if compress {
+		w.Header().Add("Vary", "Accept-Encoding")
+		cw = httpCompressor(w, httpNegotiateEncoding(r.Header.Get("Accept-Encoding")))
+	}
+
+	if status != 0 {
+		w.WriteHeader(status)
+	}
+
+	if r.Method == http.MethodHead {
+		return nil
+	}
+
+	if cw == nil {
+		return encode(w, data)
+	}
+
+	if err := encode(cw, data); err != nil {
+		_ = cw.Close()
+		return err
+	}
+
+	return cw.Close()
+}
+
+// httpCompressor sets the Content-Encoding header and returns the compressing writer, or nil for no compression
+func httpCompressor(w http.ResponseWriter, encoding string) io.WriteCloser {
+	switch encoding {
+	case "gzip":
+		w.Header().Set("Content-Encoding", encoding)
+		w.Header().Del("Content-Length")
+
+		return gzip.NewWriter(w)
+	case "deflate":
+		// HTTP deflate is the zlib format, not raw deflate
+		w.Header().Set("Content-Encoding", encoding)
+		w.Header().Del("Content-Length")
+
+		return zlib.NewWriter(w)
+	}
+
+	return nil
+}
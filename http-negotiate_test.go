@@ -0,0 +1,238 @@
+package handy
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPNegotiate(t *testing.T) {
+	offers := []string{"application/json", "application/xml", "text/csv"}
+
+	tcs := []struct {
+		accept         string
+		expectedOutput string
+	}{
+		{"", "application/json"},
+		{"*/*", "application/json"},
+		{"text/csv", "text/csv"},
+		{"text/*", "text/csv"},
+		{"application/xml;q=0.9, */*;q=0.8", "application/xml"},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "application/xml"},
+		{"application/json;q=0.2, text/csv;q=0.5", "text/csv"},
+		{"*/*, application/json;q=0", "application/xml"},
+		{"APPLICATION/XML", "application/xml"},
+		{"image/png", ""},
+		{"application/json;q=abc", ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", tc.accept)
+
+			if tr := HTTPNegotiate(r, offers...); tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %q, \n\tGot: %q", tc.expectedOutput, tr)
+			}
+		})
+	}
+}
+
+func TestHTTPNegotiateEncoding(t *testing.T) {
+	tcs := []struct {
+		acceptEncoding string
+		expectedOutput string
+	}{
+		{"", ""},
+		{"gzip, deflate, br", "gzip"},
+		{"deflate", "deflate"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"*", "gzip"},
+		{"*, gzip;q=0", "deflate"},
+		{"identity", ""},
+		{"br", ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			if tr := httpNegotiateEncoding(tc.acceptEncoding); tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %q, \n\tGot: %q", tc.expectedOutput, tr)
+			}
+		})
+	}
+}
+
+type httpTestOrder struct {
+	ID      int       `xml:"id" json:"id" csv:"id"`
+	Client  string    `xml:"client" json:"client" csv:"client"`
+	Created time.Time `xml:"created" json:"created" csv:"created"`
+	Notes   *string   `xml:"-" json:"-" csv:"-"`
+}
+
+func (o httpTestOrder) String() string {
+	return fmt.Sprintf("#%d %s", o.ID, o.Client)
+}
+
+func TestHTTPAnswer(t *testing.T) {
+	created := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	orders := []httpTestOrder{{1, "Ana", created, nil}, {2, "Bob, Jr.", created, nil}}
+
+	tcs := []struct {
+		accept              string
+		data                interface{}
+		expectedContentType string
+		expectedBody        string
+	}{
+		{"application/json", orders, "application/json; charset=utf-8",
+			`[{"id":1,"client":"Ana","created":"2024-03-01T10:00:00Z"},{"id":2,"client":"Bob, Jr.","created":"2024-03-01T10:00:00Z"}]` + "\n"},
+		{"application/json", []httpTestOrder{}, "application/json; charset=utf-8", "[]\n"},
+		{"application/json", map[string]int{"total": 2}, "application/json; charset=utf-8", `{"total":2}` + "\n"},
+		{"application/xml", orders[:1], "application/xml; charset=utf-8",
+			`<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<items><httpTestOrder><id>1</id><client>Ana</client><created>2024-03-01T10:00:00Z</created></httpTestOrder></items>`},
+		{"text/csv", orders, "text/csv; charset=utf-8", "id,client,created\n1,Ana,2024-03-01T10:00:00Z\n2,\"Bob, Jr.\",2024-03-01T10:00:00Z\n"},
+		{"text/plain", orders, "text/plain; charset=utf-8", "#1 Ana\n#2 Bob, Jr.\n"},
+		{"text/plain", 42, "text/plain; charset=utf-8", "42"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.accept, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			r.Header.Set("Accept", tc.accept)
+
+			if err := HTTPAnswer(rec, r, http.StatusOK, tc.data); err != nil {
+				t.Fatal(err)
+			}
+
+			if rec.Header().Get("Content-Type") != tc.expectedContentType || rec.Body.String() != tc.expectedBody {
+				t.Errorf("Test has failed!\n\tExpected: %s %q, \n\tGot: %s %q", tc.expectedContentType, tc.expectedBody, rec.Header().Get("Content-Type"), rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTPAnswerStreaming(t *testing.T) {
+	const n = 10000
+
+	ch := make(chan httpTestOrder)
+
+	go func() {
+		for i := 1; i <= n; i++ {
+			ch <- httpTestOrder{ID: i, Client: "client"}
+		}
+
+		close(ch)
+	}()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := HTTPAnswerCompressed(w, r, http.StatusOK, (<-chan httpTestOrder)(ch)); err != nil {
+			t.Error(err)
+		}
+	}))
+
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	req.Header.Set("Accept", "text/csv")
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	res, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	gz, err := gzip.NewReader(res.Body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadAll(gz)
+
+	if err != nil || res.Header.Get("Content-Encoding") != "gzip" || len(b) < n*10 {
+		t.Errorf("Test has failed!\n\tGot: %s, %d bytes, %v", res.Header.Get("Content-Encoding"), len(b), err)
+	}
+}
+
+func TestHTTPAnswerCompressedDeflate(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Encoding", "deflate")
+
+	if err := HTTPAnswerCompressed(rec, r, http.StatusOK, []int{1, 2, 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zlib.NewReader(rec.Body)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := ioutil.ReadAll(zr)
+
+	if string(b) != "[1,2,3]\n" || rec.Header().Get("Content-Encoding") != "deflate" {
+		t.Errorf("Test has failed!\n\tGot: %q", b)
+	}
+}
+
+func TestHTTPAnswerNotAcceptable(t *testing.T) {
+	HTTPEncoderRegister("application/x-test", func(w io.Writer, v interface{}) error {
+		_, err := fmt.Fprintf(w, "test:%v", v)
+		return err
+	})
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "application/x-test")
+
+	if err := HTTPAnswer(rec, r, http.StatusOK, 7); err != nil || rec.Body.String() != "test:7" {
+		t.Errorf("Test has failed!\n\tGot: %q, %v", rec.Body.String(), err)
+	}
+
+	rec = httptest.NewRecorder()
+	r.Header.Set("Accept", "image/png")
+
+	if err := HTTPAnswer(rec, r, http.StatusOK, 7); err != nil || rec.Code != http.StatusNotAcceptable || rec.Header().Get("Content-Type") != HTTPProblemContentType {
+		t.Errorf("Test has failed!\n\tGot: %d %q, %v", rec.Code, rec.Body.String(), err)
+	}
+
+	if err := HTTPAnswerJSON(httptest.NewRecorder(), `{"broken":`); err != ErrHTTPInvalidJSON {
+		t.Errorf("Test has failed!\n\tExpected ErrHTTPInvalidJSON, got %v", err)
+	}
+}
+
+func TestHTTPEncodeCSVMixedTypes(t *testing.T) {
+	type other struct {
+		A, B, C, D string
+	}
+
+	tcs := []struct {
+		summary string
+		items   []interface{}
+	}{
+		{"fewer fields", []interface{}{httpTestOrder{ID: 1}, struct{ ID int }{2}}},
+		{"more fields", []interface{}{struct{ ID int }{1}, httpTestOrder{ID: 2}}},
+		{"same number of fields", []interface{}{httpTestOrder{ID: 1}, other{}}},
+		{"row then struct", []interface{}{[]string{"x", "y"}, struct{ ID int }{1}}},
+		{"struct then row", []interface{}{struct{ ID int }{1}, []string{"x", "y"}}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if err := httpEncodeCSV(ioutil.Discard, tc.items); !errors.Is(err, ErrHTTPUnsupportedData) {
+				t.Errorf("Test has failed!\n\tExpected ErrHTTPUnsupportedData, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package handy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// HTTPProblemContentType is the media type of RFC 7807 problem details
+const HTTPProblemContentType = "application/problem+json"
+
+// HTTPProblem is an RFC 7807 problem details object, written by HTTPAnswerProblem()
+// It's an error too, so handlers may return it with all the members already decided.
+type HTTPProblem struct {
+	// Type is a URI identifying the problem type. When empty, "about:blank" is written
+	Type string
+	// Title is a short summary of the problem type. When empty, the status text is written
+	Title string
+	// Status is the HTTP status code. When zero, 500 is written
+	Status int
+	// Detail explains this occurrence of the problem
+	Detail string
+	// Instance is a URI identifying this occurrence, usually the request path
+	Instance string
+	// InvalidParams lists the parameters that failed validation, written as "invalid-params"
+	InvalidParams []HTTPInvalidParam
+	// Extensions are additional members, written at the top level of the object
+	Extensions map[string]interface{}
+}
+
+// HTTPInvalidParam is an item of the "invalid-params" member
+type HTTPInvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Error returns the title and the detail
+func (p HTTPProblem) Error() string {
+	if p.Detail == "" {
+		return p.title()
+	}
+
+	return p.title() + ": " + p.Detail
+}
+
+func (p HTTPProblem) status() int {
+	if p.Status == 0 {
+		return http.StatusInternalServerError
+	}
+
+	return p.Status
+}
+
+func (p HTTPProblem) title() string {
+	if p.Title == "" {
+		return http.StatusText(p.status())
+	}
+
+	return p.Title
+}
+
+// MarshalJSON writes the standard members, with the defaults of empty ones, and the extensions
+// Extensions named as standard members are ignored.
+func (p HTTPProblem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+6)
+
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+
+	for _, k := range []string{"detail", "instance", "invalid-params"} {
+		delete(m, k)
+	}
+
+	m["type"] = Tif(p.Type == "", "about:blank", p.Type)
+	m["title"] = p.title()
+	m["status"] = p.status()
+
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+
+	if len(p.InvalidParams) > 0 {
+		m["invalid-params"] = p.InvalidParams
+	}
+
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON reads a problem, keeping the unknown members as extensions
+func (p *HTTPProblem) UnmarshalJSON(b []byte) error {
+	var m map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+
+	*p = HTTPProblem{}
+
+	targets := map[string]interface{}{
+		"type":           &p.Type,
+		"title":          &p.Title,
+		"status":         &p.Status,
+		"detail":         &p.Detail,
+		"instance":       &p.Instance,
+		"invalid-params": &p.InvalidParams,
+	}
+
+	for k, raw := range m {
+		if target, ok := targets[k]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var v interface{}
+
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return err
+		}
+
+		if p.Extensions == nil {
+			p.Extensions = map[string]interface{}{}
+		}
+
+		p.Extensions[k] = v
+	}
+
+	return nil
+}
+
+var (
+	httpErrorStatuses = []httpErrorStatus{
+		{ErrHTTPParamMissing, http.StatusBadRequest},
+		{ErrHTTPParamMalformed, http.StatusBadRequest},
+		{ErrHTTPBodyEmpty, http.StatusBadRequest},
+		{ErrHTTPBodyMalformed, http.StatusBadRequest},
+		{ErrHTTPBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{ErrHTTPUnsupportedMediaType, http.StatusUnsupportedMediaType},
+	}
+	httpErrorStatusesMu sync.RWMutex
+)
+
+type httpErrorStatus struct {
+	target error
+	status int
+}
+
+// HTTPErrorStatusRegister maps an error to the status of the problems built by HTTPProblemFromError()
+// Any error matching target with errors.Is() gets the status. The latest registration wins over earlier ones.
+// Example: HTTPErrorStatusRegister(sql.ErrNoRows, http.StatusNotFound)
+func HTTPErrorStatusRegister(target error, status int) {
+	httpErrorStatusesMu.Lock()
+	defer httpErrorStatusesMu.Unlock()
+
+	httpErrorStatuses = append(httpErrorStatuses, httpErrorStatus{target, status})
+}
+
+// httpRegisteredStatus returns the status registered for the error, or zero
+func httpRegisteredStatus(err error) int {
+	httpErrorStatusesMu.RLock()
+	defer httpErrorStatusesMu.RUnlock()
+
+	for i := len(httpErrorStatuses) - 1; i >= 0; i-- {
+		if errors.Is(err, httpErrorStatuses[i].target) {
+			return httpErrorStatuses[i].status
+		}
+	}
+
+	return 0
+}
+
+// HTTPProblemFromError builds the problem details describing an error
+// An HTTPProblem in the chain is returned as it is. Otherwise the status comes from HTTPErrorStatusRegister(),
+// where handy's request errors are already registered, or is 400 Bad Request for validation error sets, or 500 Internal Server Error.
+// Validation error sets, that are HTTPParamErrors, ValidationErrors, EnvErrors and CheckStrErrors, and their single items,
+// fill "invalid-params". See HTTPInvalidParams()
+// The error text is written as detail only for 4xx statuses, so server errors don't leak internals to clients.
+func HTTPProblemFromError(err error) HTTPProblem {
+	var p HTTPProblem
+
+	if errors.As(err, &p) {
+		return p
+	}
+
+	p.InvalidParams = HTTPInvalidParams(err)
+	p.Status = httpRegisteredStatus(err)
+
+	if p.Status == 0 {
+		p.Status = Tif(len(p.InvalidParams) > 0, http.StatusBadRequest, http.StatusInternalServerError).(int)
+	}
+
+	if p.Status >= 400 && p.Status < 500 {
+		p.Detail = strings.TrimPrefix(err.Error(), "handy: ")
+	} else {
+		p.InvalidParams = nil
+	}
+
+	return p
+}
+
+// HTTPInvalidParams returns the "invalid-params" items of validation error sets found in the error chain, or nil
+// HTTPParamErrors are named by parameter, ValidationErrors and HTTPDecodeError by field path, and EnvErrors by variable.
+// CheckStrErrors don't know the field they checked, so each violation is named by its rule, like "deny-numbers".
+// To name them, wrap them: HTTPParamErrors{{Param: "password", Err: CheckStrAll(pwd, 8, 0, rules)}}
+func HTTPInvalidParams(err error) []HTTPInvalidParam {
+	var (
+		paramErrs      HTTPParamErrors
+		paramErr       HTTPParamError
+		validationErrs ValidationErrors
+		validationErr  ValidationError
+		decodeErr      HTTPDecodeError
+		envErrs        EnvErrors
+		envErr         EnvError
+		checkStrErrs   CheckStrErrors
+	)
+
+	var a []HTTPInvalidParam
+
+	switch {
+	case errors.As(err, &paramErrs):
+		for _, e := range paramErrs {
+			a = append(a, HTTPInvalidParam{e.Param, e.Message()})
+		}
+	case errors.As(err, &paramErr):
+		a = append(a, HTTPInvalidParam{paramErr.Param, paramErr.Message()})
+	case errors.As(err, &validationErrs):
+		for _, e := range validationErrs {
+			a = append(a, HTTPInvalidParam{e.Field, e.Message("")})
+		}
+	case errors.As(err, &validationErr):
+		a = append(a, HTTPInvalidParam{validationErr.Field, validationErr.Message("")})
+	case errors.As(err, &decodeErr):
+		if decodeErr.Field != "" {
+			a = append(a, HTTPInvalidParam{decodeErr.Field, decodeErr.Msg})
+		}
+	case errors.As(err, &envErrs):
+		for _, e := range envErrs {
+			a = append(a, HTTPInvalidParam{e.Var, strings.TrimPrefix(e.Err.Error(), "handy: ")})
+		}
+	case errors.As(err, &envErr):
+		a = append(a, HTTPInvalidParam{envErr.Var, strings.TrimPrefix(envErr.Err.Error(), "handy: ")})
+	case errors.As(err, &checkStrErrs):
+		for _, v := range checkStrErrs {
+			a = append(a, HTTPInvalidParam{v.Rule, v.Message("")})
+		}
+	}
+
+	return a
+}
+
+// HTTPAnswerProblem writes the problem details with its status and the application/problem+json content type
+func HTTPAnswerProblem(w http.ResponseWriter, p HTTPProblem) error {
+	return httpAnswer(w, p.status(), HTTPProblemContentType, p)
+}
+
+// HTTPAnswerError writes the problem details describing the error, with the request path as instance. See HTTPProblemFromError()
+// When the request has an ID, given by HTTPRequestID(), it's written as the "request_id" member.
+// Example: if err := HTTPBind(r, &params); err != nil { _ = HTTPAnswerError(w, r, err); return }
+func HTTPAnswerError(w http.ResponseWriter, r *http.Request, err error) error {
+	p := HTTPProblemFromError(err)
+
+	if r == nil {
+		return HTTPAnswerProblem(w, p)
+	}
+
+	if p.Instance == "" && r.URL != nil {
+		p.Instance = r.URL.Path
+	}
+
+	if id := HTTPRequestIDFrom(r.Context()); id != "" {
+		if _, ok := p.Extensions["request_id"]; !ok {
+			extensions := map[string]interface{}{"request_id": id}
+
+			for k, v := range p.Extensions {
+				extensions[k] = v
+			}
+
+			p.Extensions = extensions
+		}
+	}
+
+	return HTTPAnswerProblem(w, p)
+}
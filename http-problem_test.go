@@ -0,0 +1,117 @@
+package handy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPProblemFromError(t *testing.T) {
+	errNotFound := errors.New("order not found")
+	errHidden := errors.New("connection refused by 10.0.0.7")
+
+	HTTPErrorStatusRegister(errNotFound, http.StatusNotFound)
+
+	tcs := []struct {
+		summary        string
+		err            error
+		expectedStatus int
+		expectedDetail string
+		expectedParams []HTTPInvalidParam
+	}{
+		{"registered", fmt.Errorf("loading: %w", errNotFound), http.StatusNotFound, "loading: order not found", nil},
+		{"unknown", errHidden, http.StatusInternalServerError, "", nil},
+		{"problem", HTTPProblem{Status: http.StatusConflict, Detail: "version mismatch"}, http.StatusConflict, "version mismatch", nil},
+		{"param errors", HTTPParamErrors{{Source: HTTPTagQuery, Param: "page", Err: ErrHTTPParamMissing}}, http.StatusBadRequest,
+			`query parameter "page": handy: required parameter is missing`, []HTTPInvalidParam{{"page", "required parameter is missing"}}},
+		{"env errors", EnvErrors{{Var: "DB_PORT", Err: ErrEnvMissing}}, http.StatusBadRequest,
+			`environment variable "DB_PORT": handy: required environment variable isn't set`,
+			[]HTTPInvalidParam{{"DB_PORT", "required environment variable isn't set"}}},
+		{"check str", CheckStrAll("abc", 5, 0, CheckStrAllowEmpty), http.StatusBadRequest, "",
+			[]HTTPInvalidParam{{"min-length", CheckStrAll("abc", 5, 0, CheckStrAllowEmpty).Error()}}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := HTTPProblemFromError(tc.err)
+
+			if tc.expectedDetail == "" && tr.Status < 500 {
+				tc.expectedDetail = tc.err.Error()
+			}
+
+			if tr.Status != tc.expectedStatus || tr.Detail != tc.expectedDetail || !reflect.DeepEqual(tr.InvalidParams, tc.expectedParams) {
+				t.Errorf("Test has failed!\n\tExpected: %d %q %v, \n\tGot: %d %q %v", tc.expectedStatus, tc.expectedDetail, tc.expectedParams, tr.Status, tr.Detail, tr.InvalidParams)
+			}
+		})
+	}
+}
+
+func TestHTTPAnswerError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/orders?page=x", nil)
+
+	var params struct {
+		Page int `query:"page"`
+	}
+
+	if err := HTTPAnswerError(rec, r, HTTPBind(r, &params)); err != nil {
+		t.Fatal(err)
+	}
+
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != HTTPProblemContentType {
+		t.Errorf("Test has failed!\n\tGot: %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var m map[string]interface{}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if m["type"] != "about:blank" || m["title"] != "Bad Request" || m["status"] != 400.0 || m["instance"] != "/orders" {
+		t.Errorf("Test has failed!\n\tGot: %s", rec.Body.String())
+	}
+
+	if ip, ok := m["invalid-params"].([]interface{}); !ok || len(ip) != 1 || ip[0].(map[string]interface{})["name"] != "page" {
+		t.Errorf("Test has failed!\n\tGot: %s", rec.Body.String())
+	}
+}
+
+func TestHTTPProblemJSON(t *testing.T) {
+	p := HTTPProblem{
+		Type:       "https://example.com/probs/out-of-credit",
+		Title:      "You do not have enough credit.",
+		Status:     http.StatusForbidden,
+		Detail:     "Your current balance is 30, but that costs 50.",
+		Instance:   "/account/12345/msgs/abc",
+		Extensions: map[string]interface{}{"balance": 30.0, "accounts": []interface{}{"/account/12345"}, "status": 200.0},
+	}
+
+	b, err := json.Marshal(p)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tr HTTPProblem
+
+	if err := json.Unmarshal(b, &tr); err != nil {
+		t.Fatal(err)
+	}
+
+	delete(p.Extensions, "status")
+
+	if !reflect.DeepEqual(tr, p) {
+		t.Errorf("Test has failed!\n\tExpected: %+v, \n\tGot: %+v", p, tr)
+	}
+
+	rec := httptest.NewRecorder()
+
+	if err := HTTPAnswerJSONStatus(rec, http.StatusCreated, map[string]int{"id": 7}); err != nil || rec.Code != http.StatusCreated || rec.Body.String() != `{"id":7}` {
+		t.Errorf("Test has failed!\n\tGot: %d %s %v", rec.Code, rec.Body.String(), err)
+	}
+}
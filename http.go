@@ -2,13 +2,27 @@ package handy
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 )
 
 // HTTPRequestAsString gets a parameter coming from a http request as string, truncated to maxLength
 // Only maxLength >= 1 is considered. Otherwise, it's ignored
 func HTTPRequestAsString(r *http.Request, key string, maxLength int, transformOptions ...uint) string {
+	p := NewPipeline()
+
+	if len(transformOptions) > 0 {
+		p = PipelineFromFlags(maxLength, transformOptions[0])
+	}
+
+	return HTTPRequestAsStringWith(r, key, p.Then(TransformStepTruncate(maxLength)))
+}
+
+// HTTPRequestAsStringWith gets a parameter coming from a http request as string, transformed by the given pipeline
+// Example: HTTPRequestAsStringWith(r, "name", NewPipeline(TransformStepTrim, TransformStepTitleCase, TransformStepTruncate(50)))
+func HTTPRequestAsStringWith(r *http.Request, key string, p Pipeline) string {
 	if err := r.ParseForm(); err != nil {
 		log.Println(r.RequestURI, err)
 		return ""
@@ -24,23 +38,11 @@ func HTTPRequestAsString(r *http.Request, key string, maxLength int, transformOp
 		}
 	}
 
-	if len(transformOptions) > 0 {
-		s = Transform(s, maxLength, transformOptions[0])
-	}
-
-	if s == "" {
-		return ""
-	}
-
-	if (maxLength > 0) && (len([]rune(s)) >= maxLength) {
-		return s[0:maxLength]
-	}
-
-	return s
+	return p.Apply(s)
 }
 
-// HTTPRequestAsInteger gets a parameter coming from a http request as an integer
-// It tries to guess if it's a signed/negative integer
+// HTTPRequestAsInteger gets a parameter coming from a http request as an integer, or 0 when it's missing or malformed
+// Use HTTPBind() to tell a missing parameter from an invalid one
 func HTTPRequestAsInteger(r *http.Request, key string) int {
 	if err := r.ParseForm(); err != nil {
 		return 0
@@ -56,12 +58,10 @@ func HTTPRequestAsInteger(r *http.Request, key string) int {
 		}
 	}
 
-	neg := s[0:1] == "-"
+	i, err := strconv.Atoi(s)
 
-	i := StringAsInteger(s)
-
-	if neg && (i > 0) {
-		return i * -1
+	if err != nil {
+		return 0
 	}
 
 	return i
@@ -92,6 +92,8 @@ func HTTPRequestAsFloat64(r *http.Request, key string, decimalSeparator rune) fl
 
 // HTTPJSONBodyToStruct decode json to a given anatomically compatible struct
 // THIS ROUTINE IS BEEN DEPRECATED. Use HTTPJSONToStruct() instead.
+//
+// Deprecated: use HTTPDecodeJSON(), that limits the body size and tells what is wrong with it.
 func HTTPJSONBodyToStruct(r *http.Request, targetStruct interface{}) bool {
 	decoder := json.NewDecoder(r.Body)
 
@@ -104,6 +106,7 @@ func HTTPJSONBodyToStruct(r *http.Request, targetStruct interface{}) bool {
 // the differences to HTTPJSONBodyToStruct is that:
 // - HTTPJSONToStruct can condittionally close body after unmarshalling
 // - HTTPJSONToStruct returns an error instead of a bool
+// It has no body size limit nor strict checks. HTTPDecodeJSON() is preferred for new code.
 func HTTPJSONToStruct(r *http.Request, targetStruct interface{}, closeBody bool) error {
 	decoder := json.NewDecoder(r.Body)
 
@@ -120,11 +123,30 @@ func HTTPJSONToStruct(r *http.Request, targetStruct interface{}, closeBody bool)
 	return err
 }
 
+// ErrHTTPInvalidJSON is returned by HTTPAnswerJSON() when given a string that isn't valid json
+var ErrHTTPInvalidJSON = errors.New("handy: invalid json string")
+
 // HTTPAnswerJSON converts the given data as json, set the content-type header and write it to requester
+// A string is taken as json already, and written as it is after validation. Use HTTPAnswer() to negotiate other formats
 func HTTPAnswerJSON(w http.ResponseWriter, data interface{}) error {
+	return httpAnswer(w, 0, "application/json; charset=utf-8", data)
+}
+
+// HTTPAnswerJSONStatus works like HTTPAnswerJSON(), writing the given status code
+// Example: HTTPAnswerJSONStatus(w, http.StatusCreated, user)
+func HTTPAnswerJSONStatus(w http.ResponseWriter, status int, data interface{}) error {
+	return httpAnswer(w, status, "application/json; charset=utf-8", data)
+}
+
+// httpAnswer writes data as json, with the status when it's not zero. A string is taken as json already, if valid
+func httpAnswer(w http.ResponseWriter, status int, contentType string, data interface{}) error {
 	var jb []byte
 
 	if j1, ok := data.(string); ok {
+		if !json.Valid([]byte(j1)) {
+			return ErrHTTPInvalidJSON
+		}
+
 		jb = []byte(j1)
 	} else {
 		if j2, err := json.Marshal(data); err != nil {
@@ -134,7 +156,11 @@ func HTTPAnswerJSON(w http.ResponseWriter, data interface{}) error {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Type", contentType)
+
+	if status != 0 {
+		w.WriteHeader(status)
+	}
 
 	if _, errw := w.Write(jb); errw != nil {
 		return errw
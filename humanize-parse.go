@@ -0,0 +1,331 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrHumanizeParse is wrapped by the errors of HumanizeParseDuration and HumanizeParseTime, to be tested with errors.Is()
+var ErrHumanizeParse = errors.New("handy: unrecognized duration or time")
+
+// humanizeUnitWords maps english and portuguese unit names, without accents, to their length
+var humanizeUnitWords = map[string]time.Duration{
+	"ns": time.Nanosecond, "us": time.Microsecond, "ms": time.Millisecond,
+
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"seg": time.Second, "segundo": time.Second, "segundos": time.Second,
+
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"minuto": time.Minute, "minutos": time.Minute,
+
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"hora": time.Hour, "horas": time.Hour,
+
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour, "dia": 24 * time.Hour, "dias": 24 * time.Hour,
+
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "wks": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+	"sem": 7 * 24 * time.Hour, "semana": 7 * 24 * time.Hour, "semanas": 7 * 24 * time.Hour,
+
+	"mo": 30 * 24 * time.Hour, "month": 30 * 24 * time.Hour, "months": 30 * 24 * time.Hour,
+	"mes": 30 * 24 * time.Hour, "meses": 30 * 24 * time.Hour,
+
+	"y": 365 * 24 * time.Hour, "yr": 365 * 24 * time.Hour, "yrs": 365 * 24 * time.Hour, "year": 365 * 24 * time.Hour, "years": 365 * 24 * time.Hour,
+	"ano": 365 * 24 * time.Hour, "anos": 365 * 24 * time.Hour,
+}
+
+// humanizeOneWords are read as the number one, as in "an hour" or "uma semana"
+var humanizeOneWords = map[string]bool{"a": true, "an": true, "one": true, "um": true, "uma": true}
+
+// humanizeWeekdays maps english and portuguese weekday names and abbreviations, without accents
+var humanizeWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "domingo": time.Sunday, "dom": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "segunda": time.Monday, "segunda-feira": time.Monday, "seg": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "terca": time.Tuesday, "terca-feira": time.Tuesday, "ter": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "quarta": time.Wednesday, "quarta-feira": time.Wednesday, "qua": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "quinta": time.Thursday, "quinta-feira": time.Thursday, "qui": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "sexta": time.Friday, "sexta-feira": time.Friday, "sex": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sabado": time.Saturday, "sab": time.Saturday,
+}
+
+// humanizeNormalize lowercases, folds accents and collapses spaces
+func humanizeNormalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(ASCIIFold(s))), " ")
+}
+
+// HumanizeParseDuration reads a duration written as Go does, like "2h30m", or in english or portuguese words,
+// like "3 dias", "1 hour and 15 minutes" or "uma semana". Decimals are accepted with point or comma: "1,5 hora".
+// Months and years are taken as 30 and 365 days
+func HumanizeParseDuration(s string) (time.Duration, error) {
+	src := humanizeNormalize(s)
+
+	if src == "" {
+		return 0, fmt.Errorf("%w: empty duration", ErrHumanizeParse)
+	}
+
+	sign := time.Duration(1)
+
+	switch src[0] {
+	case '-':
+		sign = -1
+		src = src[1:]
+	case '+':
+		src = src[1:]
+	}
+
+	var (
+		total time.Duration
+		found bool
+	)
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		if c == ' ' || c == ',' {
+			i++
+			continue
+		}
+
+		start := i
+
+		// Numbers, like "2" or "1.5", or words that mean one, like "an"
+		var (
+			number float64
+			err    error
+		)
+
+		if c >= '0' && c <= '9' || c == '.' {
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' || src[i] == ',' && i+1 < len(src) && src[i+1] >= '0' && src[i+1] <= '9') {
+				i++
+			}
+
+			if number, err = strconv.ParseFloat(strings.Replace(src[start:i], ",", ".", 1), 64); err != nil {
+				return 0, fmt.Errorf("%w: invalid number %q in %q", ErrHumanizeParse, src[start:i], s)
+			}
+		} else {
+			word := humanizeWord(src, &i)
+
+			switch {
+			case word == "and" || word == "e":
+				continue
+			case humanizeOneWords[word]:
+				number = 1
+			default:
+				return 0, fmt.Errorf("%w: unexpected %q in %q", ErrHumanizeParse, word, s)
+			}
+		}
+
+		for i < len(src) && src[i] == ' ' {
+			i++
+		}
+
+		unit, ok := humanizeUnitWords[humanizeWord(src, &i)]
+
+		if !ok {
+			return 0, fmt.Errorf("%w: missing or unknown unit after %q in %q", ErrHumanizeParse, src[start:i], s)
+		}
+
+		total += time.Duration(number * float64(unit))
+		found = true
+	}
+
+	if !found {
+		return 0, fmt.Errorf("%w: no duration in %q", ErrHumanizeParse, s)
+	}
+
+	return sign * total, nil
+}
+
+// humanizeWord reads a run of letters and hyphens
+func humanizeWord(s string, i *int) string {
+	start := *i
+
+	for *i < len(s) && (unicode.IsLetter(rune(s[*i])) || s[*i] == '-') {
+		*i++
+	}
+
+	return s[start:*i]
+}
+
+// humanizeClock reads a time of day like "14:00", "14h", "14h30" or "2pm"
+func humanizeClock(s string) (hour, minute int, ok bool) {
+	s = strings.Replace(s, " ", "", -1)
+
+	pm, am := strings.HasSuffix(s, "pm"), strings.HasSuffix(s, "am")
+
+	if pm || am {
+		s = s[:len(s)-2]
+	}
+
+	separator := strings.IndexAny(s, ":h")
+
+	var err error
+
+	if separator < 0 {
+		hour, err = strconv.Atoi(s)
+	} else {
+		if hour, err = strconv.Atoi(s[:separator]); err == nil && separator+1 < len(s) {
+			minute, err = strconv.Atoi(s[separator+1:])
+		}
+	}
+
+	if err != nil || hour < 0 || minute < 0 || minute > 59 {
+		return 0, 0, false
+	}
+
+	if pm || am {
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+
+		if pm && hour < 12 {
+			hour += 12
+		} else if am && hour == 12 {
+			hour = 0
+		}
+	}
+
+	return hour, minute, hour < 24
+}
+
+// HumanizeParseTime reads a time relative to now, written in english or portuguese, like "tomorrow", "next monday",
+// "in 3 days", "2 hours ago", "há 3 dias", "ontem às 14:00", "próxima sexta" or "last week"
+// Days, like "tomorrow" or "friday", resolve to midnight in now's location, unless a time of day is given, like "at 14:00".
+// Durations, like "in 2 hours", are added to now itself. A weekday alone means its next occurrence, after today
+func HumanizeParseTime(s string, now time.Time) (time.Time, error) {
+	src := humanizeNormalize(s)
+
+	fail := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrHumanizeParse, s)
+	}
+
+	if src == "" {
+		return fail()
+	}
+
+	if src == "now" || src == "agora" {
+		return now, nil
+	}
+
+	// Durations relative to now
+	for _, prefix := range []string{"in ", "em ", "daqui a ", "daqui "} {
+		if strings.HasPrefix(src, prefix) {
+			if d, err := HumanizeParseDuration(src[len(prefix):]); err == nil {
+				return now.Add(d), nil
+			}
+		}
+	}
+
+	for _, suffix := range []string{" ago", " atras"} {
+		if strings.HasSuffix(src, suffix) {
+			if d, err := HumanizeParseDuration(src[:len(src)-len(suffix)]); err == nil {
+				return now.Add(-d), nil
+			}
+		}
+	}
+
+	if strings.HasPrefix(src, "ha ") {
+		if d, err := HumanizeParseDuration(src[3:]); err == nil {
+			return now.Add(-d), nil
+		}
+	}
+
+	// A time of day at the end, like "at 14:00" or "às 14h"
+	hour, minute, withClock := 0, 0, false
+
+	for _, separator := range []string{" at ", " as ", " a ", " "} {
+		if i := strings.LastIndex(src, separator); i > 0 {
+			if h, m, ok := humanizeClock(src[i+len(separator):]); ok {
+				hour, minute, withClock = h, m, true
+				src = src[:i]
+				break
+			}
+		}
+	}
+
+	day, wholeDay, ok := humanizeDay(src, now)
+
+	if !ok {
+		return fail()
+	}
+
+	if !wholeDay && !withClock {
+		return day, nil
+	}
+
+	y, m, d := day.Date()
+
+	return time.Date(y, m, d, hour, minute, 0, 0, now.Location()), nil
+}
+
+// humanizeDay resolves the day part of HumanizeParseTime
+// wholeDay is false for "next week" and alike, that return now moved by the unit, keeping the time of day
+func humanizeDay(src string, now time.Time) (t time.Time, wholeDay bool, ok bool) {
+	switch src {
+	case "today", "hoje":
+		return now, true, true
+	case "tomorrow", "amanha":
+		return now.AddDate(0, 0, 1), true, true
+	case "yesterday", "ontem":
+		return now.AddDate(0, 0, -1), true, true
+	case "day after tomorrow", "depois de amanha":
+		return now.AddDate(0, 0, 2), true, true
+	case "day before yesterday", "anteontem":
+		return now.AddDate(0, 0, -2), true, true
+	}
+
+	direction, name := 1, src
+
+	for _, prefix := range []string{"next ", "proxima ", "proximo ", "this "} {
+		if strings.HasPrefix(src, prefix) {
+			name = src[len(prefix):]
+		}
+	}
+
+	for _, prefix := range []string{"last ", "ultima ", "ultimo "} {
+		if strings.HasPrefix(src, prefix) {
+			direction, name = -1, src[len(prefix):]
+		}
+	}
+
+	for _, suffix := range []string{" passada", " passado", " que vem"} {
+		if strings.HasSuffix(src, suffix) {
+			name = src[:len(src)-len(suffix)]
+
+			if suffix != " que vem" {
+				direction = -1
+			}
+		}
+	}
+
+	switch name {
+	case "week", "semana":
+		return now.AddDate(0, 0, 7*direction), false, true
+	case "month", "mes":
+		// Period clamps to the month end, so next month of Jan 31 is Feb 28, not Mar 3
+		return Period{Months: 1, Negative: direction < 0}.AddTo(now), false, true
+	case "year", "ano":
+		return Period{Years: 1, Negative: direction < 0}.AddTo(now), false, true
+	}
+
+	wd, ok := humanizeWeekdays[name]
+
+	if !ok {
+		return time.Time{}, false, false
+	}
+
+	days := (int(wd) - int(now.Weekday()) + 7) % 7
+
+	if direction < 0 {
+		days = -((int(now.Weekday()) - int(wd) + 7) % 7)
+	}
+
+	if days == 0 {
+		days = 7 * direction
+	}
+
+	return now.AddDate(0, 0, days), true, true
+}
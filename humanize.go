@@ -0,0 +1,224 @@
+package handy
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// HumanizeUnit is a calendar unit used to write durations and relative times
+type HumanizeUnit uint8
+
+const (
+	// HumanizeSecond and the following constants are the units, from the smallest to the biggest
+	HumanizeSecond HumanizeUnit = iota
+	HumanizeMinute
+	HumanizeHour
+	HumanizeDay
+	HumanizeWeek
+	HumanizeMonth
+	HumanizeYear
+)
+
+// HumanizeRounding tells what to do with the part smaller than the last unit written
+type HumanizeRounding uint8
+
+const (
+	// HumanizeRoundDown discards the remainder: 2h50m with one unit is "2 hours"
+	HumanizeRoundDown HumanizeRounding = iota
+	// HumanizeRoundNearest rounds half up: 2h50m with one unit is "3 hours"
+	HumanizeRoundNearest
+	// HumanizeRoundUp rounds any remainder up: 2h01m with one unit is "3 hours"
+	HumanizeRoundUp
+)
+
+// humanizeUnitSeconds holds the length of each unit. Months and years have fixed lengths, of 30 and 365 days
+var humanizeUnitSeconds = [...]int64{1, 60, 3600, 86400, 7 * 86400, 30 * 86400, 365 * 86400}
+
+// humanizeUnitLimit holds how many of each unit make the next one, when that's exact
+var humanizeUnitLimit = [...]int64{60, 60, 24, 7, 0, 12, 0}
+
+var humanizeUnitKeys = [...]string{"humanize.second", "humanize.minute", "humanize.hour", "humanize.day", "humanize.week", "humanize.month", "humanize.year"}
+
+// HumanizeOptions holds the parametrization for HumanizeDuration and HumanizeRelative
+type HumanizeOptions struct {
+	// Locale is the language tag of the messages, from DefaultCatalog. Default is "en"
+	Locale string
+	// MaxUnits limits how many units are written, like "2 hours" (1) or "2 hours and 30 minutes" (2). Default is 1
+	MaxUnits int
+	// MinUnit is the smallest unit written. Default is HumanizeSecond
+	MinUnit HumanizeUnit
+	// Rounding applies to the last unit written. Default is HumanizeRoundDown
+	Rounding HumanizeRounding
+	// CalendarDays makes HumanizeRelative write times from yesterday and tomorrow as "yesterday at 14:00" and "tomorrow at 09:30"
+	CalendarDays bool
+}
+
+func (o HumanizeOptions) normalized() HumanizeOptions {
+	if o.Locale == "" {
+		o.Locale = CatalogFallbackLanguage
+	}
+
+	if o.MaxUnits < 1 {
+		o.MaxUnits = 1
+	}
+
+	if o.MinUnit > HumanizeYear {
+		o.MinUnit = HumanizeYear
+	}
+
+	return o
+}
+
+// humanizeParts writes the units, already rounded, according the options. It returns an empty string when all units are zero
+func humanizeParts(parts [7]int64, o HumanizeOptions) string {
+	first := func() HumanizeUnit {
+		for u := HumanizeYear; u > o.MinUnit; u-- {
+			if parts[u] > 0 {
+				return u
+			}
+		}
+
+		return o.MinUnit
+	}
+
+	last := func(first HumanizeUnit) HumanizeUnit {
+		if int(first)-o.MaxUnits+1 > int(o.MinUnit) {
+			return first - HumanizeUnit(o.MaxUnits) + 1
+		}
+
+		return o.MinUnit
+	}
+
+	high := first()
+	low := last(high)
+
+	var rest int64
+
+	for u := HumanizeSecond; u < low; u++ {
+		rest += parts[u] * humanizeUnitSeconds[u]
+		parts[u] = 0
+	}
+
+	switch {
+	case rest == 0:
+	case o.Rounding == HumanizeRoundUp:
+		parts[low]++
+	case o.Rounding == HumanizeRoundNearest && rest*2 >= humanizeUnitSeconds[low]:
+		parts[low]++
+	}
+
+	// Rounding may complete the next unit, like 60 minutes, so carry it and select the units again
+	for u := low; u < HumanizeYear; u++ {
+		if humanizeUnitLimit[u] > 0 && parts[u] >= humanizeUnitLimit[u] {
+			parts[u] -= humanizeUnitLimit[u]
+			parts[u+1]++
+		}
+	}
+
+	high = first()
+	low = last(high)
+
+	var a []string
+
+	for u := high; ; u-- {
+		if parts[u] > 0 {
+			a = append(a, TranslatePlural(o.Locale, humanizeUnitKeys[u], int(parts[u]), nil))
+		}
+
+		if u == low {
+			break
+		}
+	}
+
+	switch len(a) {
+	case 0:
+		return ""
+	case 1:
+		return a[0]
+	}
+
+	return strings.Join(a[:len(a)-1], ", ") + " " + Translate(o.Locale, "humanize.and", nil) + " " + a[len(a)-1]
+}
+
+// durationParts breaks a duration in units with fixed lengths
+func durationParts(d time.Duration) [7]int64 {
+	var parts [7]int64
+
+	seconds := int64(math.Abs(d.Seconds()))
+
+	for u := HumanizeYear; ; u-- {
+		parts[u] = seconds / humanizeUnitSeconds[u]
+		seconds %= humanizeUnitSeconds[u]
+
+		if u == HumanizeSecond {
+			break
+		}
+	}
+
+	return parts
+}
+
+// HumanizeDuration writes a duration in words, like "2 hours and 30 minutes" or "3 dias"
+// Months and years are taken as 30 and 365 days. The sign is ignored.
+// A duration smaller than MinUnit is written as zero of that unit, like "0 minutes"
+func HumanizeDuration(d time.Duration, o HumanizeOptions) string {
+	o = o.normalized()
+
+	if s := humanizeParts(durationParts(d), o); s != "" {
+		return s
+	}
+
+	return TranslatePlural(o.Locale, humanizeUnitKeys[o.MinUnit], 0, nil)
+}
+
+// HumanizeRelative writes the time relative to now, like "3 days ago", "em 2 semanas" or "yesterday at 14:00"
+// Years, months and days are counted by the calendar, as PeriodBetween() does.
+// When nothing is left after rounding to MinUnit, it returns "just now"
+func HumanizeRelative(t, now time.Time, o HumanizeOptions) string {
+	o = o.normalized()
+
+	if o.CalendarDays {
+		local := t.In(now.Location())
+
+		y1, m1, d1 := local.Date()
+		y2, m2, d2 := now.Date()
+
+		days := int(math.Round(time.Date(y1, m1, d1, 12, 0, 0, 0, time.UTC).Sub(time.Date(y2, m2, d2, 12, 0, 0, 0, time.UTC)).Hours() / 24))
+
+		if days == -1 || days == 1 {
+			key := "humanize.yesterday"
+
+			if days == 1 {
+				key = "humanize.tomorrow"
+			}
+
+			clock := DateTimeAsStringLocale(local, Translate(o.Locale, "humanize.time_format", nil), o.Locale)
+
+			return Translate(o.Locale, key, CatalogParams{"time": clock})
+		}
+	}
+
+	var parts [7]int64
+
+	p := PeriodBetween(now, t)
+
+	parts[HumanizeYear] = int64(p.Years)
+	parts[HumanizeMonth] = int64(p.Months)
+	parts[HumanizeWeek] = int64(p.Days / 7)
+	parts[HumanizeDay] = int64(p.Days % 7)
+	parts[HumanizeHour] = int64(p.Hours)
+	parts[HumanizeMinute] = int64(p.Minutes)
+	parts[HumanizeSecond] = int64(p.Seconds)
+
+	s := humanizeParts(parts, o)
+
+	switch {
+	case s == "":
+		return Translate(o.Locale, "humanize.now", nil)
+	case p.Negative:
+		return Translate(o.Locale, "humanize.ago", CatalogParams{"duration": s})
+	}
+
+	return Translate(o.Locale, "humanize.in", CatalogParams{"duration": s})
+}
@@ -0,0 +1,171 @@
+package handy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHumanizeDuration(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		duration       time.Duration
+		options        HumanizeOptions
+		expectedOutput string
+	}{
+		{"default", 2*time.Hour + 50*time.Minute, HumanizeOptions{}, "2 hours"},
+		{"singular", time.Hour, HumanizeOptions{}, "1 hour"},
+		{"two units", 2*time.Hour + 30*time.Minute, HumanizeOptions{MaxUnits: 2}, "2 hours and 30 minutes"},
+		{"three units", 26*time.Hour + 30*time.Minute + 5*time.Second, HumanizeOptions{MaxUnits: 3}, "1 day, 2 hours and 30 minutes"},
+		{"skip zero units", 24*time.Hour + 5*time.Minute, HumanizeOptions{MaxUnits: 2}, "1 day"},
+		{"round nearest", 2*time.Hour + 50*time.Minute, HumanizeOptions{Rounding: HumanizeRoundNearest}, "3 hours"},
+		{"round nearest down", 2*time.Hour + 29*time.Minute, HumanizeOptions{Rounding: HumanizeRoundNearest}, "2 hours"},
+		{"round up", 2*time.Hour + time.Second, HumanizeOptions{Rounding: HumanizeRoundUp}, "3 hours"},
+		{"round with carry", 59*time.Minute + 40*time.Second, HumanizeOptions{Rounding: HumanizeRoundNearest}, "1 hour"},
+		{"min unit", 40 * time.Second, HumanizeOptions{MinUnit: HumanizeMinute}, "0 minutes"},
+		{"weeks", 15 * 24 * time.Hour, HumanizeOptions{MaxUnits: 2}, "2 weeks and 1 day"},
+		{"negative", -3 * time.Minute, HumanizeOptions{}, "3 minutes"},
+		{"portuguese", 3 * 24 * time.Hour, HumanizeOptions{Locale: "pt-BR"}, "3 dias"},
+		{"portuguese two units", 15*24*time.Hour + time.Hour, HumanizeOptions{Locale: "pt-BR", MaxUnits: 2}, "2 semanas e 1 dia"},
+		{"portuguese zero", 0, HumanizeOptions{Locale: "pt-BR"}, "0 segundos"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := HumanizeDuration(tc.duration, tc.options)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tDuration: %s, \n\tExpected: %s, \n\tGot: %s", tc.duration, tc.expectedOutput, tr)
+			}
+		})
+	}
+}
+
+func TestHumanizeRelative(t *testing.T) {
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		summary        string
+		time           time.Time
+		options        HumanizeOptions
+		expectedOutput string
+	}{
+		{"days ago", now.AddDate(0, 0, -3), HumanizeOptions{}, "3 days ago"},
+		{"in hours", now.Add(2 * time.Hour), HumanizeOptions{}, "in 2 hours"},
+		{"just now", now.Add(-20 * time.Second), HumanizeOptions{MinUnit: HumanizeMinute}, "just now"},
+		{"calendar months", time.Date(2026, 8, 18, 10, 0, 0, 0, time.UTC), HumanizeOptions{}, "2 months ago"},
+		{"portuguese past", now.AddDate(0, 0, -3), HumanizeOptions{Locale: "pt-BR"}, "há 3 dias"},
+		{"portuguese future", now.AddDate(0, 0, 14), HumanizeOptions{Locale: "pt-BR"}, "em 2 semanas"},
+		{"yesterday", time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC), HumanizeOptions{CalendarDays: true}, "yesterday at 14:00"},
+		{"tomorrow", time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC), HumanizeOptions{CalendarDays: true}, "tomorrow at 09:30"},
+		{"ontem", time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC), HumanizeOptions{Locale: "pt-BR", CalendarDays: true}, "ontem às 14:00"},
+		{"same day with calendar days", now.Add(-3 * time.Hour), HumanizeOptions{CalendarDays: true}, "3 hours ago"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := HumanizeRelative(tc.time, now, tc.options)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tTime: %s, \n\tExpected: %s, \n\tGot: %s", tc.time, tc.expectedOutput, tr)
+			}
+		})
+	}
+}
+
+func TestHumanizeParseDuration(t *testing.T) {
+	tcs := []struct {
+		input          string
+		expectedOutput time.Duration
+	}{
+		{"2h30m", 2*time.Hour + 30*time.Minute},
+		{"1h 15min", time.Hour + 15*time.Minute},
+		{"3 dias", 3 * 24 * time.Hour},
+		{"1 hour and 15 minutes", time.Hour + 15*time.Minute},
+		{"2 horas e 30 minutos", 2*time.Hour + 30*time.Minute},
+		{"an hour", time.Hour},
+		{"uma semana", 7 * 24 * time.Hour},
+		{"1,5 hora", 90 * time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"2 Meses", 60 * 24 * time.Hour},
+		{"-10s", -10 * time.Second},
+		{"500ms", 500 * time.Millisecond},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			tr, err := HumanizeParseDuration(tc.input)
+
+			if err != nil || tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s, \n\tExpected: %s, \n\tGot: %s, %v", tc.input, tc.expectedOutput, tr, err)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "abc", "3", "3 potatoes", "and"} {
+		if _, err := HumanizeParseDuration(input); !errors.Is(err, ErrHumanizeParse) {
+			t.Errorf("Test has failed!\n\tInput: %q, \n\tExpected: %v, \n\tGot: %v", input, ErrHumanizeParse, err)
+		}
+	}
+}
+
+func TestHumanizeParseTime(t *testing.T) {
+	// 2026-10-18 is a sunday
+	now := time.Date(2026, 10, 18, 10, 15, 0, 0, time.UTC)
+
+	tcs := []struct {
+		input          string
+		expectedOutput time.Time
+	}{
+		{"now", now},
+		{"in 2 hours", now.Add(2 * time.Hour)},
+		{"3 days ago", now.AddDate(0, 0, -3)},
+		{"há 3 dias", now.AddDate(0, 0, -3)},
+		{"em 2 semanas", now.AddDate(0, 0, 14)},
+		{"daqui a 10 minutos", now.Add(10 * time.Minute)},
+		{"tomorrow", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"yesterday at 14:00", time.Date(2026, 10, 17, 14, 0, 0, 0, time.UTC)},
+		{"ontem às 14h30", time.Date(2026, 10, 17, 14, 30, 0, 0, time.UTC)},
+		{"amanhã 2pm", time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC)},
+		{"next monday", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"next sunday", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"friday", time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)},
+		{"próxima sexta às 9:00", time.Date(2026, 10, 23, 9, 0, 0, 0, time.UTC)},
+		{"last friday", time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{"sábado passado", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{"next week", now.AddDate(0, 0, 7)},
+		{"mês que vem", now.AddDate(0, 1, 0)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			tr, err := HumanizeParseTime(tc.input, now)
+
+			if err != nil || !tr.Equal(tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tInput: %s, \n\tExpected: %s, \n\tGot: %s, %v", tc.input, tc.expectedOutput, tr, err)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "someday", "next potato", "in a while"} {
+		if _, err := HumanizeParseTime(input, now); !errors.Is(err, ErrHumanizeParse) {
+			t.Errorf("Test has failed!\n\tInput: %q, \n\tExpected: %v, \n\tGot: %v", input, ErrHumanizeParse, err)
+		}
+	}
+	monthEnd := []struct {
+		input          string
+		now            time.Time
+		expectedOutput time.Time
+	}{
+		{"next month", time.Date(2026, 1, 31, 10, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{"last month", time.Date(2026, 3, 31, 10, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{"mês passado", time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC)},
+		{"next year", time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range monthEnd {
+		if tr, err := HumanizeParseTime(tc.input, tc.now); err != nil || !tr.Equal(tc.expectedOutput) {
+			t.Errorf("Test has failed!\n\tInput: %s from %s, \n\tExpected: %s, \n\tGot: %s, %v", tc.input, tc.now, tc.expectedOutput, tr, err)
+		}
+	}
+}
@@ -74,11 +74,15 @@ func CheckPersonName(name string, acceptEmpty bool) uint8 {
 // NameFirstAndLast returns the first and last words/names from the given input, optionally transformed by transformFlags
 // Example: handy.NameFirstAndLast("friedrich wilhelm nietzsche", handy.TransformFlagTitleCase) // returns "Friedrich Nietzsche"
 func NameFirstAndLast(name string, transformFlags uint) string {
+	return NameFirstAndLastWith(name, namePipeline(transformFlags))
+}
+
+// NameFirstAndLastWith returns the first and last words/names from the given input, transformed by the given pipeline
+// Example: handy.NameFirstAndLastWith("friedrich wilhelm nietzsche", handy.NewPipeline(handy.TransformStepTitleCase)) // returns "Friedrich Nietzsche"
+func NameFirstAndLastWith(name string, p Pipeline) string {
 	name = strings.Replace(name, "\t", ` `, -1)
 
-	if transformFlags != TransformNone {
-		name = Transform(name, utf8.RuneCountInString(name), transformFlags)
-	}
+	name = p.Apply(name)
 
 	name = strings.TrimSpace(name)
 
@@ -104,11 +108,15 @@ func NameFirstAndLast(name string, transformFlags uint) string {
 // NameFirst returns the first word/name from the given input, optionally transformed by transformFlags
 // Example: handy.NameFirst("friedrich wilhelm nietzsche", handy.TransformFlagTitleCase) // returns "Friedrich"
 func NameFirst(name string, transformFlags uint) string {
+	return NameFirstWith(name, namePipeline(transformFlags))
+}
+
+// NameFirstWith returns the first word/name from the given input, transformed by the given pipeline
+// Example: handy.NameFirstWith("friedrich wilhelm nietzsche", handy.NewPipeline(handy.TransformStepUpperCase)) // returns "FRIEDRICH"
+func NameFirstWith(name string, p Pipeline) string {
 	name = strings.Replace(name, "\t", ` `, -1)
 
-	if transformFlags != TransformNone {
-		name = Transform(name, utf8.RuneCountInString(name), transformFlags)
-	}
+	name = p.Apply(name)
 
 	name = strings.TrimSpace(name)
 
@@ -127,6 +135,11 @@ func NameFirst(name string, transformFlags uint) string {
 
 // NameInitials returns the first and last words/names from the given input, optionally transformed by transformFlags
 func NameInitials(name string, transformFlags uint) string {
+	return NameInitialsWith(name, namePipeline(transformFlags))
+}
+
+// NameInitialsWith returns the initials from the given input, transformed by the given pipeline
+func NameInitialsWith(name string, p Pipeline) string {
 	name = strings.TrimSpace(name)
 
 	name = strings.Replace(name, "\t", ` `, -1)
@@ -135,9 +148,7 @@ func NameInitials(name string, transformFlags uint) string {
 		return ``
 	}
 
-	if transformFlags != TransformNone {
-		name = Transform(name, utf8.RuneCountInString(name), transformFlags)
-	}
+	name = p.Apply(name)
 
 	words := strings.Split(name, ` `)
 
@@ -158,3 +169,12 @@ func NameInitials(name string, transformFlags uint) string {
 
 	return strings.Join(a, ` `)
 }
+
+// namePipeline keeps the legacy behavior of name functions, where TransformNone skips transformations at all
+func namePipeline(transformFlags uint) Pipeline {
+	if transformFlags == TransformNone {
+		return NewPipeline()
+	}
+
+	return PipelineFromFlags(0, transformFlags)
+}
@@ -0,0 +1,426 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrPeriodParse is wrapped by every error returned by PeriodParse, to be tested with errors.Is()
+var ErrPeriodParse = errors.New("handy: invalid ISO-8601 period")
+
+// Period is an amount of time in calendar units, like "1 year, 2 months and 3 days"
+// Unlike time.Duration, months and years don't have a fixed length: they only make sense when added to a date.
+// The components are usually positive, with Negative telling the direction.
+type Period struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+	Negative    bool
+}
+
+// PeriodBetween returns the calendar difference between two dates, like ElapsedTime(), but keeping the nanoseconds
+// The period is Negative when "to" is before "from". If locations are different, "to" is converted to "from" location
+func PeriodBetween(from, to time.Time) Period {
+	if from.Location() != to.Location() {
+		to = to.In(from.Location())
+	}
+
+	var p Period
+
+	if from.Equal(to) {
+		return p
+	}
+
+	// For correct calculations, assure from is before to
+	if from.After(to) {
+		from, to = to, from
+		p.Negative = true
+	}
+
+	y1, M1, d1 := from.Date()
+	y2, M2, d2 := to.Date()
+
+	h1, m1, s1 := from.Clock()
+	h2, m2, s2 := to.Clock()
+
+	p.Years = y2 - y1
+	p.Months = int(M2 - M1)
+	p.Days = d2 - d1
+	p.Hours = h2 - h1
+	p.Minutes = m2 - m1
+	p.Seconds = s2 - s1
+	p.Nanoseconds = to.Nanosecond() - from.Nanosecond()
+
+	// Normalize negative values
+	if p.Nanoseconds < 0 {
+		p.Nanoseconds += int(time.Second)
+		p.Seconds--
+	}
+
+	if p.Seconds < 0 {
+		p.Seconds += 60
+		p.Minutes--
+	}
+
+	if p.Minutes < 0 {
+		p.Minutes += 60
+		p.Hours--
+	}
+
+	if p.Hours < 0 {
+		p.Hours += 24
+		p.Days--
+	}
+
+	if p.Days < 0 {
+		// days in month:
+		t := time.Date(y1, M1, 32, 0, 0, 0, 0, time.UTC)
+
+		p.Days += 32 - t.Day()
+
+		p.Months--
+	}
+
+	if p.Months < 0 {
+		p.Months += 12
+
+		p.Years--
+	}
+
+	return p
+}
+
+// PeriodFromDuration splits a duration in hours, minutes, seconds and nanoseconds
+func PeriodFromDuration(d time.Duration) Period {
+	var p Period
+
+	if d < 0 {
+		p.Negative = true
+		d = -d
+	}
+
+	p.Hours = int(d / time.Hour)
+	p.Minutes = int(d % time.Hour / time.Minute)
+	p.Seconds = int(d % time.Minute / time.Second)
+	p.Nanoseconds = int(d % time.Second)
+
+	return p
+}
+
+func (p Period) sign() int {
+	if p.Negative {
+		return -1
+	}
+
+	return 1
+}
+
+// clock returns the hours, minutes, seconds and nanoseconds as a duration, without the sign
+func (p Period) clock() time.Duration {
+	return time.Duration(p.Hours)*time.Hour + time.Duration(p.Minutes)*time.Minute + time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+}
+
+// IsZero returns true when all the components are zero
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0 && p.clock() == 0
+}
+
+// Neg returns the period with the opposite direction
+func (p Period) Neg() Period {
+	p.Negative = !p.Negative
+
+	return p
+}
+
+// AddTo returns t moved by the period. Years and months go first, then days and finally the clock components.
+// When the resulting month is shorter, the day is clamped to its last day: january 31st plus one month is february 28th (or 29th).
+// Days are calendar days, so "P1D" keeps the time of day across daylight saving changes, while "PT24H" doesn't
+func (p Period) AddTo(t time.Time) time.Time {
+	sign := p.sign()
+
+	if months := sign * (p.Years*12 + p.Months); months != 0 {
+		y, m, d := t.Date()
+		h, mi, s := t.Clock()
+
+		// time.Date normalizes months out of range, like 14 or -1
+		first := time.Date(y, m+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+
+		if last := MonthLastDay(first.Year(), int(first.Month())); d > last {
+			d = last
+		}
+
+		t = time.Date(first.Year(), first.Month(), d, h, mi, s, t.Nanosecond(), t.Location())
+	}
+
+	if p.Days != 0 {
+		t = t.AddDate(0, 0, sign*p.Days)
+	}
+
+	return t.Add(time.Duration(sign) * p.clock())
+}
+
+// Normalized returns an equivalent period with months up to 11, minutes and seconds up to 59
+// Only exact conversions are made: months go to years, and seconds go to minutes and hours. Hours never become days,
+// as a calendar day may have 23 or 25 hours, and days never become months.
+// When every component has the same sign, the result has only positive components and the sign goes to Negative.
+func (p Period) Normalized() Period {
+	sign := p.sign()
+
+	months := sign * (p.Years*12 + p.Months)
+	days := sign * p.Days
+	clock := time.Duration(sign) * p.clock()
+
+	var n Period
+
+	if months <= 0 && days <= 0 && clock <= 0 {
+		n.Negative = true
+		months, days, clock = -months, -days, -clock
+	}
+
+	n.Years, n.Months = months/12, months%12
+	n.Days = days
+	n.Hours = int(clock / time.Hour)
+	n.Minutes = int(clock % time.Hour / time.Minute)
+	n.Seconds = int(clock % time.Minute / time.Second)
+	n.Nanoseconds = int(clock % time.Second)
+
+	if n.IsZero() {
+		n.Negative = false
+	}
+
+	return n
+}
+
+// Equal returns true when both periods are the same after normalization, so "PT90M" equals "PT1H30M"
+func (p Period) Equal(q Period) bool {
+	return p.Normalized() == q.Normalized()
+}
+
+// Compare returns -1, 0 or 1 when p is shorter, equal or longer than q, both added to the given reference date
+// Periods with months or years can't be compared without a date: "P1M" is shorter than "P30D" when starting in february
+func (p Period) Compare(q Period, at time.Time) int {
+	a, b := p.AddTo(at), q.AddTo(at)
+
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+
+	return 0
+}
+
+// String returns the period in ISO-8601 format, like "P1Y2M3DT4H5M6.5S". The zero period is "PT0S"
+// A negative period is prefixed by a minus sign: "-P1D"
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+
+	if p.Negative {
+		sb.WriteByte('-')
+	}
+
+	sb.WriteByte('P')
+
+	write := func(n int, designator byte) {
+		if n != 0 {
+			sb.WriteString(strconv.Itoa(n))
+			sb.WriteByte(designator)
+		}
+	}
+
+	write(p.Years, 'Y')
+	write(p.Months, 'M')
+	write(p.Days, 'D')
+
+	seconds := time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+
+	if p.Hours != 0 || p.Minutes != 0 || seconds != 0 {
+		sb.WriteByte('T')
+
+		write(p.Hours, 'H')
+		write(p.Minutes, 'M')
+
+		if seconds != 0 {
+			s := strconv.FormatInt(int64(seconds/time.Second), 10)
+
+			if fraction := seconds % time.Second; fraction != 0 {
+				if fraction < 0 {
+					fraction = -fraction
+
+					if seconds > -time.Second {
+						s = "-" + s
+					}
+				}
+
+				s += strings.TrimRight(fmt.Sprintf(".%09d", fraction), "0")
+			}
+
+			sb.WriteString(s)
+			sb.WriteByte('S')
+		}
+	}
+
+	return sb.String()
+}
+
+// MarshalText writes the period in ISO-8601 format, so it goes to JSON as a string
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText reads a period in ISO-8601 format
+func (p *Period) UnmarshalText(b []byte) error {
+	x, err := PeriodParse(string(b))
+
+	if err != nil {
+		return err
+	}
+
+	*p = x
+
+	return nil
+}
+
+// PeriodParse reads an ISO-8601 duration, like "P1Y2M3DT4H5M6.5S", "P2W" or "-PT15M"
+// Weeks are turned into days. Only seconds accept a fraction, with point or comma. Designators are case insensitive
+func PeriodParse(s string) (Period, error) {
+	var p Period
+
+	fail := func(reason string) (Period, error) {
+		return Period{}, fmt.Errorf("%w: %s in %q", ErrPeriodParse, reason, s)
+	}
+
+	src := strings.ToUpper(strings.TrimSpace(s))
+
+	switch {
+	case strings.HasPrefix(src, "-"):
+		p.Negative = true
+		src = src[1:]
+	case strings.HasPrefix(src, "+"):
+		src = src[1:]
+	}
+
+	if !strings.HasPrefix(src, "P") {
+		return fail(`expected "P"`)
+	}
+
+	src = src[1:]
+
+	if src == "" || src == "T" {
+		return fail("no components")
+	}
+
+	const order = "YMWDTHMS"
+
+	inTime, last := false, -1
+
+	for src != "" {
+		if src[0] == 'T' {
+			if inTime {
+				return fail(`repeated "T"`)
+			}
+
+			inTime, last = true, strings.IndexByte(order, 'T')
+			src = src[1:]
+
+			if src == "" {
+				return fail(`nothing after "T"`)
+			}
+
+			continue
+		}
+
+		i := 0
+
+		for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.' || src[i] == ',' || i == 0 && src[i] == '-') {
+			i++
+		}
+
+		if i == 0 || i >= len(src) {
+			return fail("expected a number followed by a designator")
+		}
+
+		number, designator := strings.Replace(src[:i], ",", ".", 1), src[i]
+		src = src[i+1:]
+
+		// "M" means months before "T", and minutes after it
+		position := strings.IndexByte(order, designator)
+
+		if inTime && designator == 'M' {
+			position = strings.LastIndexByte(order, 'M')
+		}
+
+		if position < 0 || position <= last || inTime != (position > strings.IndexByte(order, 'T')) {
+			return fail(fmt.Sprintf("unexpected designator %q", designator))
+		}
+
+		last = position
+
+		if designator == 'S' {
+			seconds, err := strconv.ParseFloat(number, 64)
+
+			if err != nil {
+				return fail(fmt.Sprintf("invalid number %q", number))
+			}
+
+			whole, fraction := number, ""
+
+			if dot := strings.IndexByte(number, '.'); dot >= 0 {
+				whole, fraction = number[:dot], number[dot+1:]
+			}
+
+			if p.Seconds, err = strconv.Atoi(whole); err != nil && whole != "" && whole != "-" {
+				return fail(fmt.Sprintf("invalid number %q", number))
+			}
+
+			if len(fraction) > 9 {
+				fraction = fraction[:9]
+			}
+
+			nanos, _ := strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+
+			if seconds < 0 {
+				nanos = -nanos
+			}
+
+			p.Nanoseconds = nanos
+
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+
+		if err != nil {
+			return fail(fmt.Sprintf("only seconds accept fractions, got %q", number))
+		}
+
+		switch position {
+		case 0:
+			p.Years = n
+		case 1:
+			p.Months = n
+		case 2:
+			p.Days += n * 7
+		case 3:
+			p.Days += n
+		case 5:
+			p.Hours = n
+		case 6:
+			p.Minutes = n
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,161 @@
+package handy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPeriodBetween(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		from           time.Time
+		to             time.Time
+		expectedOutput Period
+	}{
+		{"same time", time.Date(2018, 10, 31, 0, 0, 0, 0, time.UTC), time.Date(2018, 10, 31, 0, 0, 0, 0, time.UTC), Period{}},
+		{"complete", time.Date(2018, 1, 31, 10, 20, 30, 500, time.UTC), time.Date(2019, 3, 2, 11, 21, 31, 1500, time.UTC), Period{Years: 1, Months: 1, Days: 2, Hours: 1, Minutes: 1, Seconds: 1, Nanoseconds: 1000}},
+		{"borrowing", time.Date(2018, 12, 31, 23, 0, 0, 0, time.UTC), time.Date(2019, 1, 1, 1, 0, 0, 0, time.UTC), Period{Hours: 2}},
+		{"negative", time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC), Period{Years: 1, Negative: true}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := PeriodBetween(tc.from, tc.to)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tFrom: %s, \n\tTo: %s, \n\tExpected: %+v, \n\tGot: %+v", tc.from, tc.to, tc.expectedOutput, tr)
+			}
+		})
+	}
+}
+
+func TestPeriodAddTo(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		period         Period
+		time           time.Time
+		expectedOutput time.Time
+	}{
+		{"month end clamping", Period{Months: 1}, time.Date(2019, 1, 31, 10, 0, 0, 0, time.UTC), time.Date(2019, 2, 28, 10, 0, 0, 0, time.UTC)},
+		{"leap year clamping", Period{Years: 1}, time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"months across years", Period{Months: 14}, time.Date(2019, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"negative", Period{Months: 1, Days: 1, Negative: true}, time.Date(2019, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2019, 2, 27, 0, 0, 0, 0, time.UTC)},
+		{"clock", Period{Hours: 25, Minutes: 30}, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 1, 2, 1, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := tc.period.AddTo(tc.time)
+
+			if !tr.Equal(tc.expectedOutput) {
+				t.Errorf("Test has failed!\n\tPeriod: %s, \n\tTime: %s, \n\tExpected: %s, \n\tGot: %s", tc.period, tc.time, tc.expectedOutput, tr)
+			}
+		})
+	}
+
+	from := time.Date(2017, 5, 14, 8, 30, 15, 250, time.UTC)
+	to := time.Date(2019, 11, 3, 22, 10, 5, 100, time.UTC)
+
+	if tr := PeriodBetween(from, to).AddTo(from); !tr.Equal(to) {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", to, tr)
+	}
+
+	if tr := PeriodBetween(to, from).AddTo(to); !tr.Equal(from) {
+		t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", from, tr)
+	}
+}
+
+func TestPeriodString(t *testing.T) {
+	tcs := []struct {
+		period         Period
+		expectedOutput string
+	}{
+		{Period{}, "PT0S"},
+		{Period{Years: 1, Months: 2, Days: 3, Hours: 4}, "P1Y2M3DT4H"},
+		{Period{Minutes: 5, Seconds: 6, Nanoseconds: 500000000}, "PT5M6.5S"},
+		{Period{Nanoseconds: 1500}, "PT0.0000015S"},
+		{Period{Days: 1, Negative: true}, "-P1D"},
+	}
+
+	for _, tc := range tcs {
+		if tr := tc.period.String(); tr != tc.expectedOutput {
+			t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s", tc.expectedOutput, tr)
+		}
+	}
+}
+
+func TestPeriodParse(t *testing.T) {
+	tcs := []struct {
+		input          string
+		expectedOutput Period
+	}{
+		{"P1Y2M3DT4H", Period{Years: 1, Months: 2, Days: 3, Hours: 4}},
+		{"PT5M6.5S", Period{Minutes: 5, Seconds: 6, Nanoseconds: 500000000}},
+		{"PT0,25S", Period{Nanoseconds: 250000000}},
+		{"P2W", Period{Days: 14}},
+		{"-PT15M", Period{Minutes: 15, Negative: true}},
+		{"p1m", Period{Months: 1}},
+		{"PT1M", Period{Minutes: 1}},
+		{"PT0S", Period{}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.input, func(t *testing.T) {
+			tr, err := PeriodParse(tc.input)
+
+			if err != nil || tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s, \n\tExpected: %+v, \n\tGot: %+v, %v", tc.input, tc.expectedOutput, tr, err)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "P", "PT", "1Y", "P1H", "PT1D", "P1M1Y", "P1.5Y", "P1YT", "PxY", "P1Y2"} {
+		if _, err := PeriodParse(input); !errors.Is(err, ErrPeriodParse) {
+			t.Errorf("Test has failed!\n\tInput: %q, \n\tExpected: %v, \n\tGot: %v", input, ErrPeriodParse, err)
+		}
+	}
+}
+
+func TestPeriodNormalizedAndCompare(t *testing.T) {
+	if n := (Period{Months: 14, Minutes: 90, Seconds: 75}).Normalized(); n != (Period{Years: 1, Months: 2, Hours: 1, Minutes: 31, Seconds: 15}) {
+		t.Errorf("Test has failed!\n\tGot: %+v", n)
+	}
+
+	if n := (Period{Days: -1, Hours: -2}).Normalized(); n != (Period{Days: 1, Hours: 2, Negative: true}) {
+		t.Errorf("Test has failed!\n\tGot: %+v", n)
+	}
+
+	if !(Period{Minutes: 90}).Equal(Period{Hours: 1, Minutes: 30}) || (Period{Hours: 24}).Equal(Period{Days: 1}) {
+		t.Errorf("Test has failed! Equal()")
+	}
+
+	february := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	if c := (Period{Months: 1}).Compare(Period{Days: 30}, february); c != -1 {
+		t.Errorf("Test has failed!\n\tExpected -1, got %d", c)
+	}
+
+	if c := (Period{Months: 1}).Compare(Period{Days: 28}, february); c != 0 {
+		t.Errorf("Test has failed!\n\tExpected 0, got %d", c)
+	}
+}
+
+func TestPeriodJSON(t *testing.T) {
+	var v struct {
+		Grace Period `json:"grace"`
+	}
+
+	if err := json.Unmarshal([]byte(`{"grace":"P1DT12H"}`), &v); err != nil || v.Grace != (Period{Days: 1, Hours: 12}) {
+		t.Errorf("Test has failed!\n\tGot: %+v, %v", v.Grace, err)
+	}
+
+	if b, _ := json.Marshal(v); string(b) != `{"grace":"P1DT12H"}` {
+		t.Errorf("Test has failed!\n\tGot: %s", b)
+	}
+
+	if tr := PeriodFromDuration(-(90*time.Minute + 1500*time.Millisecond)); tr != (Period{Hours: 1, Minutes: 30, Seconds: 1, Nanoseconds: 500000000, Negative: true}) {
+		t.Errorf("Test has failed!\n\tGot: %+v", tr)
+	}
+}
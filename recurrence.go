@@ -0,0 +1,538 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRecurrenceParse is wrapped by every error returned by RecurrenceParse, to be tested with errors.Is()
+var ErrRecurrenceParse = errors.New("handy: invalid recurrence rule")
+
+// RecurrenceFreq is the base frequency of a recurrence rule, the FREQ rule part
+type RecurrenceFreq int
+
+// Frequencies supported by Recurrence
+const (
+	RecurrenceDaily RecurrenceFreq = iota + 1
+	RecurrenceWeekly
+	RecurrenceMonthly
+	RecurrenceYearly
+)
+
+var recurrenceFreqNames = map[RecurrenceFreq]string{
+	RecurrenceDaily:   "DAILY",
+	RecurrenceWeekly:  "WEEKLY",
+	RecurrenceMonthly: "MONTHLY",
+	RecurrenceYearly:  "YEARLY",
+}
+
+var recurrenceWeekdayNames = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// recurrenceMaxGapYears stops the expansion of rules that would never match again, like the 31st day of february
+// Any rule that matches at all matches again within a full gregorian cycle.
+const recurrenceMaxGapYears = 400
+
+// RecurrenceWeekday is an item of the BYDAY rule part, like "MO", "2TU" (the second tuesday) or "-1FR" (the last friday)
+// N is zero for every such weekday in the period. Counting is only allowed for monthly and yearly rules.
+type RecurrenceWeekday struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// String returns the weekday as written in a rule, like "-1FR"
+func (w RecurrenceWeekday) String() string {
+	if w.N == 0 {
+		return recurrenceWeekdayNames[w.Weekday]
+	}
+
+	return strconv.Itoa(w.N) + recurrenceWeekdayNames[w.Weekday]
+}
+
+// matches tells if d is the weekday, counting it from the beginning or the end of the [first, last] period
+func (w RecurrenceWeekday) matches(d, first, last Date) bool {
+	switch {
+	case d.Weekday() != w.Weekday:
+		return false
+	case w.N > 0:
+		return first.DaysUntil(d)/7 == w.N-1
+	case w.N < 0:
+		return d.DaysUntil(last)/7 == -w.N-1
+	}
+
+	return true
+}
+
+// Recurrence is a subset of the RFC 5545 RRULE: FREQ, INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY and BYSETPOS
+// Weeks begin on monday, the RFC 5545 default WKST.
+// Occurrences keep the wall clock time of the start, so a 09:00 meeting stays at 09:00 across daylight saving changes.
+// Dates that don't exist, like the 31st of a 30-day month, are skipped, as RFC 5545 says. Use BYMONTHDAY=-1 for the last day of the month.
+type Recurrence struct {
+	Freq RecurrenceFreq
+	// Interval is the number of periods between occurrences. Zero means 1
+	Interval int
+	// Count limits the number of occurrences. Zero means no limit
+	Count int
+	// Until is the last instant an occurrence may happen, inclusive. Zero means no limit
+	Until      time.Time
+	ByDay      []RecurrenceWeekday
+	ByMonthDay []int
+	BySetPos   []int
+	// untilDate is true when UNTIL was written as a date, so it covers the whole day at the start location
+	untilDate bool
+}
+
+// RecurrenceParse reads a rule like "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", with or without the "RRULE:" prefix
+// UNTIL is accepted as a date, like "20191231", or as an UTC time, like "20191231T235959Z"
+func RecurrenceParse(s string) (Recurrence, error) {
+	var r Recurrence
+
+	fail := func(reason string) (Recurrence, error) {
+		return Recurrence{}, fmt.Errorf("%w: %s in %q", ErrRecurrenceParse, reason, s)
+	}
+
+	src := strings.ToUpper(strings.TrimSpace(s))
+	src = strings.TrimPrefix(src, "RRULE:")
+
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(src, ";") {
+		eq := strings.IndexByte(part, '=')
+
+		if eq <= 0 {
+			return fail(fmt.Sprintf("malformed part %q", part))
+		}
+
+		name, value := part[:eq], part[eq+1:]
+
+		if seen[name] {
+			return fail(fmt.Sprintf("repeated %s", name))
+		}
+
+		seen[name] = true
+
+		var err error
+
+		switch name {
+		case "FREQ":
+			for f, n := range recurrenceFreqNames {
+				if n == value {
+					r.Freq = f
+				}
+			}
+
+			if r.Freq == 0 {
+				return fail(fmt.Sprintf("unsupported FREQ %q", value))
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+
+			if err != nil || r.Interval < 1 {
+				return fail(fmt.Sprintf("invalid INTERVAL %q", value))
+			}
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+
+			if err != nil || r.Count < 1 {
+				return fail(fmt.Sprintf("invalid COUNT %q", value))
+			}
+		case "UNTIL":
+			if r.Until, err = time.Parse("20060102T150405Z", value); err == nil {
+				break
+			}
+
+			if r.Until, err = time.Parse("20060102", value); err != nil {
+				return fail(fmt.Sprintf("invalid UNTIL %q", value))
+			}
+
+			r.untilDate = true
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				w, ok := recurrenceParseWeekday(item)
+
+				if !ok {
+					return fail(fmt.Sprintf("invalid BYDAY %q", item))
+				}
+
+				r.ByDay = append(r.ByDay, w)
+			}
+		case "BYMONTHDAY":
+			if r.ByMonthDay, err = recurrenceParseInts(value, 31); err != nil {
+				return fail(fmt.Sprintf("invalid BYMONTHDAY %q", value))
+			}
+		case "BYSETPOS":
+			if r.BySetPos, err = recurrenceParseInts(value, 366); err != nil {
+				return fail(fmt.Sprintf("invalid BYSETPOS %q", value))
+			}
+		default:
+			return fail(fmt.Sprintf("unsupported rule part %s", name))
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return fail(err.Error())
+	}
+
+	return r, nil
+}
+
+func recurrenceParseWeekday(s string) (RecurrenceWeekday, bool) {
+	if len(s) < 2 {
+		return RecurrenceWeekday{}, false
+	}
+
+	w := RecurrenceWeekday{Weekday: -1}
+
+	for i, name := range recurrenceWeekdayNames {
+		if name == s[len(s)-2:] {
+			w.Weekday = time.Weekday(i)
+		}
+	}
+
+	if w.Weekday < 0 {
+		return RecurrenceWeekday{}, false
+	}
+
+	if n := s[:len(s)-2]; n != "" {
+		var err error
+
+		if w.N, err = strconv.Atoi(n); err != nil || w.N == 0 || w.N < -53 || w.N > 53 {
+			return RecurrenceWeekday{}, false
+		}
+	}
+
+	return w, true
+}
+
+// recurrenceParseInts reads a comma separated list of non-zero numbers from -max to max
+func recurrenceParseInts(s string, max int) ([]int, error) {
+	var a []int
+
+	for _, item := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(item)
+
+		if err != nil || n == 0 || n < -max || n > max {
+			return nil, fmt.Errorf("invalid number %q", item)
+		}
+
+		a = append(a, n)
+	}
+
+	return a, nil
+}
+
+// validate checks the combinations RFC 5545 doesn't allow, or this subset doesn't support
+func (r Recurrence) validate() error {
+	if _, ok := recurrenceFreqNames[r.Freq]; !ok {
+		return errors.New("FREQ is required")
+	}
+
+	if r.Interval < 0 || r.Count < 0 {
+		return errors.New("INTERVAL and COUNT can't be negative")
+	}
+
+	if r.Count > 0 && !r.Until.IsZero() {
+		return errors.New("COUNT and UNTIL can't be used together")
+	}
+
+	if r.Freq == RecurrenceWeekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY can't be used with FREQ=WEEKLY")
+	}
+
+	for _, w := range r.ByDay {
+		if w.N != 0 && r.Freq != RecurrenceMonthly && r.Freq != RecurrenceYearly {
+			return fmt.Errorf("BYDAY %s needs FREQ=MONTHLY or FREQ=YEARLY", w)
+		}
+	}
+
+	if len(r.BySetPos) > 0 && len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+		return errors.New("BYSETPOS needs BYDAY or BYMONTHDAY")
+	}
+
+	return nil
+}
+
+// String returns the rule in RFC 5545 format, without the "RRULE:" prefix
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + recurrenceFreqNames[r.Freq]}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+
+	if len(r.ByDay) > 0 {
+		a := make([]string, len(r.ByDay))
+
+		for i, w := range r.ByDay {
+			a[i] = w.String()
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(a, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+recurrenceJoinInts(r.ByMonthDay))
+	}
+
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+recurrenceJoinInts(r.BySetPos))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func recurrenceJoinInts(a []int) string {
+	s := make([]string, len(a))
+
+	for i, n := range a {
+		s[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(s, ",")
+}
+
+// Iterator returns a lazy iterator over the occurrences, beginning at start, that gives the time of day and the location
+// Only instants matching the rule are returned, so start itself is skipped when it doesn't match.
+func (r Recurrence) Iterator(start time.Time) *RecurrenceIterator {
+	if r.Interval < 1 {
+		r.Interval = 1
+	}
+
+	it := &RecurrenceIterator{rule: r, start: start, first: DateOf(start)}
+
+	if err := r.validate(); err != nil {
+		it.done = true
+	}
+
+	return it
+}
+
+// Each calls fn for every occurrence from start, in order, until fn returns false or the occurrences end
+// Rules without COUNT nor UNTIL never end, so fn must stop them.
+func (r Recurrence) Each(start time.Time, fn func(t time.Time) bool) {
+	it := r.Iterator(start)
+
+	for t, ok := it.Next(); ok && fn(t); t, ok = it.Next() {
+	}
+}
+
+// Between returns the occurrences from start that happen within the interval [from, to]
+func (r Recurrence) Between(start, from, to time.Time) []time.Time {
+	var a []time.Time
+
+	r.Each(start, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+
+		if !t.Before(from) {
+			a = append(a, t)
+		}
+
+		return true
+	})
+
+	return a
+}
+
+// RecurrenceIterator expands the occurrences of a rule one period at a time. It's not safe for concurrent use
+type RecurrenceIterator struct {
+	rule    Recurrence
+	start   time.Time
+	first   Date
+	period  int
+	pending []Date
+	count   int
+	done    bool
+	// lastFound is the date of the last occurrence, or of the start, to give up on rules that don't match anymore
+	lastFound Date
+}
+
+// Next returns the next occurrence, and false when there are no more
+func (it *RecurrenceIterator) Next() (time.Time, bool) {
+	if it.lastFound.IsZero() {
+		it.lastFound = it.first
+	}
+
+	for !it.done {
+		if len(it.pending) == 0 {
+			from, to := it.periodBounds()
+
+			if from.Year-it.lastFound.Year > recurrenceMaxGapYears {
+				it.done = true
+
+				break
+			}
+
+			it.pending = it.rule.expand(from, to, it.first)
+			it.period++
+
+			continue
+		}
+
+		d := it.pending[0]
+		it.pending = it.pending[1:]
+
+		// the wall clock is kept, time.Date() taking care of the offset of each day
+		t := time.Date(d.Year, d.Month, d.Day, it.start.Hour(), it.start.Minute(), it.start.Second(), it.start.Nanosecond(), it.start.Location())
+
+		if t.Before(it.start) {
+			continue
+		}
+
+		if it.pastUntil(d, t) {
+			it.done = true
+
+			break
+		}
+
+		it.lastFound = d
+		it.count++
+
+		if it.rule.Count > 0 && it.count >= it.rule.Count {
+			it.done = true
+		}
+
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+func (it *RecurrenceIterator) pastUntil(d Date, t time.Time) bool {
+	switch {
+	case it.rule.Until.IsZero():
+		return false
+	case it.rule.untilDate:
+		return d.After(DateOf(it.rule.Until))
+	}
+
+	return t.After(it.rule.Until)
+}
+
+// periodBounds returns the first and last days of the current period
+func (it *RecurrenceIterator) periodBounds() (Date, Date) {
+	n := it.period * it.rule.Interval
+
+	switch it.rule.Freq {
+	case RecurrenceWeekly:
+		monday := it.first.AddDays(-(int(it.first.Weekday()) + 6) % 7).AddDays(7 * n)
+
+		return monday, monday.AddDays(6)
+	case RecurrenceMonthly:
+		from := NewDate(it.first.Year, it.first.Month+time.Month(n), 1)
+
+		return from, NewDate(from.Year, from.Month+1, 0)
+	case RecurrenceYearly:
+		return NewDate(it.first.Year+n, 1, 1), NewDate(it.first.Year+n, 12, 31)
+	}
+
+	d := it.first.AddDays(n)
+
+	return d, d
+}
+
+// expand returns the matching dates of the period, in order, after BYSETPOS
+func (r Recurrence) expand(from, to, first Date) []Date {
+	var a []Date
+
+	DateRange{Start: from, End: to}.Each(func(d Date) bool {
+		if r.matches(d, from, to, first) {
+			a = append(a, d)
+		}
+
+		return true
+	})
+
+	if len(r.BySetPos) == 0 || len(a) == 0 {
+		return a
+	}
+
+	var selected []Date
+
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+
+		if pos < 0 {
+			i = len(a) + pos
+		}
+
+		if i >= 0 && i < len(a) {
+			selected = append(selected, a[i])
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+
+	// different positions may point to the same date
+	unique := selected[:0]
+
+	for i, d := range selected {
+		if i == 0 || d != selected[i-1] {
+			unique = append(unique, d)
+		}
+	}
+
+	return unique
+}
+
+// matches tells if d, within the [from, to] period, belongs to the rule
+// Without BYDAY nor BYMONTHDAY, the start date tells the weekday, the day of month or the day of year to repeat.
+func (r Recurrence) matches(d, from, to, first Date) bool {
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		switch r.Freq {
+		case RecurrenceWeekly:
+			return d.Weekday() == first.Weekday()
+		case RecurrenceMonthly:
+			return d.Day == first.Day
+		case RecurrenceYearly:
+			return d.Month == first.Month && d.Day == first.Day
+		}
+
+		return true
+	}
+
+	if len(r.ByMonthDay) > 0 && !r.matchesMonthDay(d) {
+		return false
+	}
+
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, w := range r.ByDay {
+		if w.matches(d, from, to) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesMonthDay tells if d is one of BYMONTHDAY, negative days counting from the end of the month
+func (r Recurrence) matchesMonthDay(d Date) bool {
+	last := MonthLastDay(d.Year, int(d.Month))
+
+	for _, n := range r.ByMonthDay {
+		if n == d.Day || n < 0 && last+n+1 == d.Day {
+			return true
+		}
+	}
+
+	return false
+}
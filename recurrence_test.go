@@ -0,0 +1,143 @@
+package handy
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceExpansion(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		rule           string
+		start          time.Time
+		limit          int
+		expectedOutput []string
+	}{
+		{"daily with interval", "FREQ=DAILY;INTERVAL=10;COUNT=3", time.Date(2019, 12, 25, 9, 0, 0, 0, time.UTC), 0, []string{"2019-12-25", "2020-01-04", "2020-01-14"}},
+		{"weekdays only", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=4", time.Date(2019, 6, 6, 9, 0, 0, 0, time.UTC), 0, []string{"2019-06-06", "2019-06-07", "2019-06-10", "2019-06-11"}},
+		{"every other week on monday and wednesday", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;UNTIL=20190630", time.Date(2019, 6, 5, 9, 0, 0, 0, time.UTC), 0, []string{"2019-06-05", "2019-06-17", "2019-06-19"}},
+		{"monthly on the 31st skips short months", "FREQ=MONTHLY;COUNT=4", time.Date(2019, 1, 31, 9, 0, 0, 0, time.UTC), 0, []string{"2019-01-31", "2019-03-31", "2019-05-31", "2019-07-31"}},
+		{"last day of every month", "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", time.Date(2020, 1, 31, 9, 0, 0, 0, time.UTC), 0, []string{"2020-01-31", "2020-02-29", "2020-03-31"}},
+		{"second tuesday of the month", "RRULE:FREQ=MONTHLY;BYDAY=2TU;COUNT=2", time.Date(2019, 6, 1, 9, 0, 0, 0, time.UTC), 0, []string{"2019-06-11", "2019-07-09"}},
+		{"last business day of the month", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3", time.Date(2019, 8, 1, 9, 0, 0, 0, time.UTC), 0, []string{"2019-08-30", "2019-09-30", "2019-10-31"}},
+		{"friday the 13th", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", time.Date(2019, 1, 1, 9, 0, 0, 0, time.UTC), 3, []string{"2019-09-13", "2019-12-13", "2020-03-13"}},
+		{"yearly on february 29th", "FREQ=YEARLY", time.Date(2020, 2, 29, 9, 0, 0, 0, time.UTC), 2, []string{"2020-02-29", "2024-02-29"}},
+		{"last monday of the year", "FREQ=YEARLY;BYDAY=-1MO;COUNT=2", time.Date(2019, 1, 1, 9, 0, 0, 0, time.UTC), 0, []string{"2019-12-30", "2020-12-28"}},
+		{"never matches", "FREQ=MONTHLY;BYMONTHDAY=31;BYDAY=2MO", time.Date(2019, 1, 1, 9, 0, 0, 0, time.UTC), 5, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			r, err := RecurrenceParse(tc.rule)
+
+			if err != nil {
+				t.Fatalf("Test has failed!\n\tRule: %s, \n\tError: %v", tc.rule, err)
+			}
+
+			var tr []string
+
+			r.Each(tc.start, func(o time.Time) bool {
+				tr = append(tr, o.Format("2006-01-02"))
+
+				if o.Hour() != 9 {
+					t.Errorf("Test has failed!\n\tExpected the time of day of the start, got %s", o)
+				}
+
+				return tc.limit == 0 || len(tr) < tc.limit
+			})
+
+			if len(tr) != len(tc.expectedOutput) {
+				t.Fatalf("Test has failed!\n\tRule: %s, \n\tExpected: %v, \n\tGot: %v", tc.rule, tc.expectedOutput, tr)
+			}
+
+			for i := range tr {
+				if tr[i] != tc.expectedOutput[i] {
+					t.Errorf("Test has failed!\n\tRule: %s, \n\tExpected: %v, \n\tGot: %v", tc.rule, tc.expectedOutput, tr)
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	r, _ := RecurrenceParse("FREQ=DAILY;COUNT=3")
+
+	// daylight saving time began on 2019-03-10 in New York
+	var tr []time.Time
+
+	r.Each(time.Date(2019, 3, 9, 9, 0, 0, 0, loc), func(o time.Time) bool {
+		tr = append(tr, o)
+
+		return true
+	})
+
+	if len(tr) != 3 {
+		t.Fatalf("Test has failed!\n\tExpected 3 occurrences, got %v", tr)
+	}
+
+	for _, o := range tr {
+		if o.Hour() != 9 {
+			t.Errorf("Test has failed!\n\tExpected 09:00 local time, got %s", o)
+		}
+	}
+
+	if d := tr[2].Sub(tr[1]); d != 24*time.Hour || tr[1].Sub(tr[0]) != 23*time.Hour {
+		t.Errorf("Test has failed!\n\tExpected a 23-hour day followed by a 24-hour one, got %v", tr)
+	}
+}
+
+func TestRecurrenceParse(t *testing.T) {
+	tcs := []struct {
+		rule           string
+		expectedOutput string
+	}{
+		{"freq=weekly;byday=mo,fr;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{"FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20191231T235959Z", "FREQ=MONTHLY;UNTIL=20191231T235959Z;BYDAY=-1FR"},
+		{"FREQ=YEARLY;UNTIL=20191231", "FREQ=YEARLY;UNTIL=20191231"},
+		{"FREQ=HOURLY", ""},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20191231", ""},
+		{"FREQ=WEEKLY;BYDAY=2MO", ""},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", ""},
+		{"FREQ=MONTHLY;BYSETPOS=1", ""},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", ""},
+		{"BYDAY=MO", ""},
+		{"FREQ=DAILY;FREQ=WEEKLY", ""},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.rule, func(t *testing.T) {
+			r, err := RecurrenceParse(tc.rule)
+
+			if tc.expectedOutput == "" {
+				if !errors.Is(err, ErrRecurrenceParse) {
+					t.Errorf("Test has failed!\n\tExpected ErrRecurrenceParse, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil || r.String() != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, %v", tc.expectedOutput, r, err)
+			}
+		})
+	}
+}
+
+func TestRecurrenceBetween(t *testing.T) {
+	r := Recurrence{Freq: RecurrenceWeekly, ByDay: []RecurrenceWeekday{{Weekday: time.Friday}}}
+
+	start := time.Date(2019, 1, 4, 18, 0, 0, 0, time.UTC)
+
+	tr := r.Between(start, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2019, 6, 30, 0, 0, 0, 0, time.UTC))
+
+	if len(tr) != 4 || tr[0].Day() != 7 || tr[3].Day() != 28 {
+		t.Errorf("Test has failed!\n\tExpected the 4 fridays of june 2019, got %v", tr)
+	}
+}
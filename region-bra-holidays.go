@@ -0,0 +1,30 @@
+package handy
+
+import "time"
+
+// HolidaysBrazil lists the brazilian national holidays
+// Carnaval and Corpus Christi are "pontos facultativos" by law, but banks and most companies close on them,
+// so they count as holidays for due dates (boletos) and deadlines, as FEBRABAN calendars do.
+var HolidaysBrazil = []HolidayRule{
+	HolidayFixed("Confraternização Universal", time.January, 1),
+	HolidayEaster("Carnaval", -48),
+	HolidayEaster("Carnaval", -47),
+	HolidayEaster("Sexta-feira Santa", -2),
+	HolidayFixed("Tiradentes", time.April, 21),
+	HolidayFixed("Dia do Trabalho", time.May, 1),
+	HolidayEaster("Corpus Christi", 60),
+	HolidayFixed("Independência do Brasil", time.September, 7),
+	HolidayFixed("Nossa Senhora Aparecida", time.October, 12),
+	HolidayFixed("Finados", time.November, 2),
+	HolidayFixed("Proclamação da República", time.November, 15),
+	// Lei 14.759/2023 made it a national holiday
+	HolidayFixed("Dia Nacional de Zumbi e da Consciência Negra", time.November, 20).Since(2024),
+	HolidayFixed("Natal", time.December, 25),
+}
+
+// BusinessCalendarBrazil returns a new calendar with saturdays and sundays as weekend, and the brazilian national holidays
+// State and city holidays can be added with Extend() or AddHoliday():
+// BusinessCalendarBrazil().Extend(HolidayFixed("Aniversário de São Paulo", time.January, 25))
+func BusinessCalendarBrazil() *BusinessCalendar {
+	return NewBusinessCalendar().AddHoliday(HolidaysBrazil...)
+}
@@ -0,0 +1,138 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	slugSeparatorDefault   = "-"
+	slugMaxAttemptsDefault = 1000
+)
+
+// ErrSlugUnavailable is returned by SlugWith when no unique candidate was found within SlugOptions.MaxAttempts,
+// or when SlugOptions.MaxLen leaves no room for the base before the numeric suffix
+var ErrSlugUnavailable = errors.New("handy: no unique slug available")
+
+// SlugOptions holds the parametrization for SlugWith
+type SlugOptions struct {
+	// Separator goes between words. Default is "-"
+	Separator string
+	// MaxLen limits the slug length in runes, cutting on word boundaries. Zero means no limit
+	MaxLen int
+	// KeepCase avoids lowercasing the slug
+	KeepCase bool
+	// Reserved lists slugs that can't be used, like "admin" or "new". Comparison is case insensitive.
+	Reserved []string
+	// Exists is called to check if a candidate slug is already taken, usually against a database
+	// When the slug is reserved or taken, a numeric suffix is appended: "my-post-2", "my-post-3" and so on
+	Exists func(slug string) bool
+	// MaxAttempts limits how many suffixes are tried before giving up. Default is 1000
+	MaxAttempts int
+}
+
+// Slug generates a lowercase, dash-separated and accent free version of the given string, suitable for URLs
+// Example: Slug("  Ação é   Válida!! ") returns "acao-e-valida"
+func Slug(s string) string {
+	slug, _ := SlugWith(s, SlugOptions{})
+
+	return slug
+}
+
+// SlugWith generates a slug from the given string according the given options
+// An empty or symbols-only input returns an empty slug and no error
+func SlugWith(s string, o SlugOptions) (string, error) {
+	if o.Separator == "" {
+		o.Separator = slugSeparatorDefault
+	}
+
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = slugMaxAttemptsDefault
+	}
+
+	words := slugWords(s)
+
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	base := strings.Join(words, o.Separator)
+
+	if !o.KeepCase {
+		base = strings.ToLower(base)
+	}
+
+	base = slugTruncate(base, o.Separator, o.MaxLen)
+
+	if !slugTaken(base, o) {
+		return base, nil
+	}
+
+	for i := 2; i <= o.MaxAttempts+1; i++ {
+		suffix := fmt.Sprintf("%s%d", o.Separator, i)
+
+		candidate := base
+
+		if o.MaxLen > 0 {
+			budget := o.MaxLen - utf8.RuneCountInString(suffix)
+
+			// no room left for the base, and longer suffixes won't make it better
+			if budget < 1 {
+				return "", ErrSlugUnavailable
+			}
+
+			candidate = slugTruncate(base, o.Separator, budget)
+		}
+
+		candidate += suffix
+
+		if !slugTaken(candidate, o) {
+			return candidate, nil
+		}
+	}
+
+	return "", ErrSlugUnavailable
+}
+
+// slugWords folds the input to ASCII and splits it into runs of letters and digits
+func slugWords(s string) []string {
+	s = ASCIIFold(s)
+
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// slugTruncate cuts the slug at the last separator that fits within maxLen
+// If the first word alone is longer than maxLen, it's cut in the middle
+func slugTruncate(slug, separator string, maxLen int) string {
+	if maxLen <= 0 || utf8.RuneCountInString(slug) <= maxLen {
+		return slug
+	}
+
+	cut := string([]rune(slug)[:maxLen])
+
+	// When the cut falls exactly on a word boundary, the whole last word fits
+	if strings.HasPrefix(string([]rune(slug)[maxLen:]), separator) {
+		return cut
+	}
+
+	if i := strings.LastIndex(cut, separator); i > 0 {
+		return cut[:i]
+	}
+
+	return cut
+}
+
+func slugTaken(slug string, o SlugOptions) bool {
+	for _, r := range o.Reserved {
+		if strings.EqualFold(r, slug) {
+			return true
+		}
+	}
+
+	return o.Exists != nil && o.Exists(slug)
+}
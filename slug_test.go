@@ -0,0 +1,76 @@
+package handy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tcs := []defaultTestStruct{
+		{"empty", "", ""},
+		{"only symbols", "#@!", ""},
+		{"simple", "Hello World", "hello-world"},
+		{"accents and spaces", "  Ação é   Válida!! ", "acao-e-valida"},
+		{"german", "Straße über alles", "strasse-uber-alles"},
+		{"separators collapsed", "a -- b __ c", "a-b-c"},
+		{"digits", "Top 10 Go Tips", "top-10-go-tips"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if r := Slug(tc.input.(string)); r != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s,\n\tExpected: %s, \n\tGot: %s", tc.input, tc.expectedOutput, r)
+			}
+		})
+	}
+}
+
+func TestSlugWith(t *testing.T) {
+	taken := map[string]bool{"my-post": true, "my-post-2": true}
+
+	exists := func(s string) bool {
+		return taken[s]
+	}
+
+	tcs := []struct {
+		summary        string
+		input          string
+		options        SlugOptions
+		expectedOutput string
+	}{
+		{"word boundary truncation", "The quick brown fox", SlugOptions{MaxLen: 12}, "the-quick"},
+		{"exact boundary", "The quick brown fox", SlugOptions{MaxLen: 9}, "the-quick"},
+		{"long single word", "Supercalifragilistic", SlugOptions{MaxLen: 5}, "super"},
+		{"custom separator", "Hello World", SlugOptions{Separator: "_"}, "hello_world"},
+		{"keep case", "Hello World", SlugOptions{KeepCase: true}, "Hello-World"},
+		{"reserved", "Admin", SlugOptions{Reserved: []string{"admin"}}, "admin-2"},
+		{"exists", "My Post", SlugOptions{Exists: exists}, "my-post-3"},
+		{"exists with max length", "My Post", SlugOptions{Exists: exists, MaxLen: 8}, "my-2"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			r, err := SlugWith(tc.input, tc.options)
+
+			if err != nil || r != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s,\n\tExpected: %s, \n\tGot: %s, %v", tc.input, tc.expectedOutput, r, err)
+			}
+		})
+	}
+
+	if _, err := SlugWith("x", SlugOptions{MaxAttempts: 3, Exists: func(string) bool { return true }}); err != ErrSlugUnavailable {
+		t.Errorf("Test has failed! Expected ErrSlugUnavailable, got %v", err)
+	}
+
+	if r, err := SlugWith("He", SlugOptions{Reserved: []string{"he"}, MaxLen: 2}); err != ErrSlugUnavailable {
+		t.Errorf("Test has failed! Expected ErrSlugUnavailable, got %s, %v", r, err)
+	}
+
+	if r, err := SlugWith("Hey", SlugOptions{Reserved: []string{"hey"}, MaxLen: 3}); err != nil || r != "h-2" {
+		t.Errorf("Test has failed!\n\tExpected: h-2, \n\tGot: %s, %v", r, err)
+	}
+
+	if r, _ := SlugWith(strings.Repeat("a ", 50), SlugOptions{MaxLen: 10}); len(r) > 10 {
+		t.Errorf("Test has failed! %s is longer than 10", r)
+	}
+}
@@ -0,0 +1,289 @@
+package handy
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// TransformStep is a single named string operation, to be chained within a Pipeline
+// Steps that have a legacy TransformFlag* equivalent carry it, so the pipeline can be turned back into flags
+type TransformStep struct {
+	name string
+	flag uint
+	// truncate marks the steps built by TransformStepTruncate, whatever their name, that are turned back into maxLen
+	truncate bool
+	maxLen   int
+	fn       func(string) string
+}
+
+// Name returns the step's name, like "trim" or "hash"
+func (ts TransformStep) Name() string {
+	return ts.name
+}
+
+// Flag returns the equivalent TransformFlag* constant, or zero when the step has no flag equivalent
+func (ts TransformStep) Flag() uint {
+	return ts.flag
+}
+
+func titleCase(s string) string {
+	return strings.Title(strings.ToLower(s))
+}
+
+var (
+	// TransformStepTrim removes leading and trailing spaces
+	TransformStepTrim = TransformStep{name: "trim", flag: TransformFlagTrim, fn: strings.TrimSpace}
+	// TransformStepLowerCase makes the string lowercase
+	TransformStepLowerCase = TransformStep{name: "lowercase", flag: TransformFlagLowerCase, fn: strings.ToLower}
+	// TransformStepUpperCase makes the string uppercase
+	TransformStepUpperCase = TransformStep{name: "uppercase", flag: TransformFlagUpperCase, fn: strings.ToUpper}
+	// TransformStepTitleCase makes the first letter of each word uppercase, and the rest lowercase
+	TransformStepTitleCase = TransformStep{name: "titlecase", flag: TransformFlagTitleCase, fn: titleCase}
+	// TransformStepOnlyDigits removes all non-numeric characters
+	TransformStepOnlyDigits = TransformStep{name: "only-digits", flag: TransformFlagOnlyDigits, fn: OnlyDigits}
+	// TransformStepOnlyLetters removes all non-letter characters
+	TransformStepOnlyLetters = TransformStep{name: "only-letters", flag: TransformFlagOnlyLetters, fn: OnlyLetters}
+	// TransformStepOnlyLettersAndDigits leaves only letters and numbers
+	TransformStepOnlyLettersAndDigits = TransformStep{name: "only-letters-and-digits", flag: TransformFlagOnlyLettersAndDigits, fn: OnlyLettersAndNumbers}
+	// TransformStepRemoveDigits removes all digit characters, without to touch on any other
+	TransformStepRemoveDigits = TransformStep{name: "remove-digits", flag: TransformFlagRemoveDigits, fn: RemoveDigits}
+	// TransformStepNormalizeNFC applies Unicode canonical composition
+	TransformStepNormalizeNFC = TransformStep{name: "nfc", flag: TransformFlagNormalizeNFC, fn: NormalizeNFC}
+	// TransformStepNormalizeNFD applies Unicode canonical decomposition
+	TransformStepNormalizeNFD = TransformStep{name: "nfd", flag: TransformFlagNormalizeNFD, fn: NormalizeNFD}
+	// TransformStepNormalizeNFKC applies Unicode compatibility composition
+	TransformStepNormalizeNFKC = TransformStep{name: "nfkc", flag: TransformFlagNormalizeNFKC, fn: NormalizeNFKC}
+	// TransformStepRemoveAccents strips diacritics, keeping the base letters
+	TransformStepRemoveAccents = TransformStep{name: "remove-accents", flag: TransformFlagRemoveAccents, fn: RemoveAccents}
+	// TransformStepASCIIFold converts the string to its closest ASCII representation
+	TransformStepASCIIFold = TransformStep{name: "ascii-fold", flag: TransformFlagASCIIFold, fn: ASCIIFold}
+	// TransformStepHash applies handy.StringHash() on the string
+	TransformStepHash = TransformStep{name: "hash", flag: TransformFlagHash, fn: StringHash}
+)
+
+// TransformStepTruncate limits the string to maxLen runes
+// If maxLen <= 0, the step does nothing
+func TransformStepTruncate(maxLen int) TransformStep {
+	return TransformStep{
+		name:     "truncate",
+		truncate: true,
+		maxLen:   maxLen,
+		fn: func(s string) string {
+			if maxLen > 0 && utf8.RuneCountInString(s) > maxLen {
+				return string([]rune(s)[:maxLen])
+			}
+
+			return s
+		},
+	}
+}
+
+// TransformStepReplace replaces all occurrences of each key of the given map by its value
+// Replacements are done in a single pass, like strings.Replacer does. Where keys overlap, the longest one wins,
+// and keys of the same length are tried in lexical order, so {"a": "1", "ab": "2"} turns "abc" into "2c".
+func TransformStepReplace(replacements map[string]string) TransformStep {
+	keys := make([]string, 0, len(replacements))
+
+	for k := range replacements {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	pairs := make([]string, 0, len(keys)*2)
+
+	for _, k := range keys {
+		pairs = append(pairs, k, replacements[k])
+	}
+
+	r := strings.NewReplacer(pairs...)
+
+	return TransformStep{name: "replace", fn: r.Replace}
+}
+
+// TransformStepFunc wraps a custom function as a named pipeline step
+func TransformStepFunc(name string, fn func(string) string) TransformStep {
+	return TransformStep{name: name, fn: fn}
+}
+
+// Pipeline is an ordered and immutable sequence of TransformSteps
+// Since a Pipeline is never modified after built, the same instance can be reused across goroutines
+type Pipeline struct {
+	steps []TransformStep
+}
+
+// NewPipeline returns a pipeline that runs the given steps in the given order
+func NewPipeline(steps ...TransformStep) Pipeline {
+	return Pipeline{steps: append([]TransformStep(nil), steps...)}
+}
+
+// Then returns a new pipeline with the given steps appended. The receiver isn't changed.
+func (p Pipeline) Then(steps ...TransformStep) Pipeline {
+	a := make([]TransformStep, 0, len(p.steps)+len(steps))
+	a = append(a, p.steps...)
+	a = append(a, steps...)
+
+	return Pipeline{steps: a}
+}
+
+// Apply runs all the pipeline steps over the given string, in order
+// As Transform does, an empty input returns an empty output without running any step
+func (p Pipeline) Apply(s string) string {
+	if s == "" {
+		return s
+	}
+
+	for _, step := range p.steps {
+		if step.fn != nil {
+			s = step.fn(s)
+		}
+	}
+
+	return s
+}
+
+// Len returns the number of steps in the pipeline
+func (p Pipeline) Len() int {
+	return len(p.steps)
+}
+
+// Names returns the step names, in execution order
+func (p Pipeline) Names() []string {
+	names := make([]string, len(p.steps))
+
+	for i, step := range p.steps {
+		names[i] = step.name
+	}
+
+	return names
+}
+
+// Flags converts the pipeline back to the legacy flags and maxLen parameters used by Transform
+// maxLen is the smallest truncation found, or zero if there's none.
+// ok is false when some step has no flag equivalent, like custom functions and replacements.
+// Observe that flags don't keep the steps order, so the result of Transform can differ from Apply.
+func (p Pipeline) Flags() (flags uint, maxLen int, ok bool) {
+	ok = true
+
+	for _, step := range p.steps {
+		switch {
+		case step.flag != 0:
+			flags |= step.flag
+		case step.truncate:
+			if step.maxLen > 0 && (maxLen == 0 || step.maxLen < maxLen) {
+				maxLen = step.maxLen
+			}
+		default:
+			ok = false
+		}
+	}
+
+	if flags == 0 {
+		flags = TransformNone
+	}
+
+	return flags, maxLen, ok
+}
+
+// PipelineFromFlags builds the pipeline equivalent to Transform(s, maxLen, flags)
+func PipelineFromFlags(maxLen int, flags uint) Pipeline {
+	if flags&TransformNone == TransformNone {
+		return NewPipeline(TransformStepTruncate(maxLen))
+	}
+
+	var steps []TransformStep
+
+	ordered := []TransformStep{
+		TransformStepNormalizeNFC,
+		TransformStepNormalizeNFD,
+		TransformStepNormalizeNFKC,
+		TransformStepRemoveAccents,
+		TransformStepASCIIFold,
+		TransformStepOnlyLettersAndDigits,
+		TransformStepOnlyDigits,
+		TransformStepOnlyLetters,
+		TransformStepRemoveDigits,
+		TransformStepTrim,
+		TransformStepTitleCase,
+		TransformStepLowerCase,
+		TransformStepUpperCase,
+		TransformStepHash,
+	}
+
+	for _, step := range ordered {
+		if flags&step.flag == step.flag {
+			steps = append(steps, step)
+		}
+	}
+
+	if maxLen > 0 {
+		steps = append(steps, TransformStepTruncate(maxLen))
+	}
+
+	// Have to trim before and after, to avoid issues with string truncation and new leading/trailing spaces
+	if flags&TransformFlagTrim == TransformFlagTrim {
+		steps = append(steps, TransformStepTrim)
+	}
+
+	return Pipeline{steps: steps}
+}
+
+// PipelineFromFlagsSerially builds the pipeline equivalent to TransformSerially(s, maxLen, flags...)
+// Unknown flags and TransformNone are ignored
+func PipelineFromFlagsSerially(maxLen int, flags ...uint) Pipeline {
+	var steps []TransformStep
+
+	for _, flag := range flags {
+		if step, ok := transformStepByFlag(flag); ok {
+			steps = append(steps, step)
+		}
+	}
+
+	if maxLen > 0 {
+		steps = append(steps, TransformStepTruncate(maxLen))
+	}
+
+	return Pipeline{steps: steps}
+}
+
+func transformStepByFlag(flag uint) (TransformStep, bool) {
+	switch flag {
+	case TransformFlagNormalizeNFC:
+		return TransformStepNormalizeNFC, true
+	case TransformFlagNormalizeNFD:
+		return TransformStepNormalizeNFD, true
+	case TransformFlagNormalizeNFKC:
+		return TransformStepNormalizeNFKC, true
+	case TransformFlagRemoveAccents:
+		return TransformStepRemoveAccents, true
+	case TransformFlagASCIIFold:
+		return TransformStepASCIIFold, true
+	case TransformFlagOnlyLettersAndDigits:
+		return TransformStepOnlyLettersAndDigits, true
+	case TransformFlagOnlyDigits:
+		return TransformStepOnlyDigits, true
+	case TransformFlagOnlyLetters:
+		return TransformStepOnlyLetters, true
+	case TransformFlagRemoveDigits:
+		return TransformStepRemoveDigits, true
+	case TransformFlagTrim:
+		return TransformStepTrim, true
+	case TransformFlagTitleCase:
+		return TransformStepTitleCase, true
+	case TransformFlagLowerCase:
+		return TransformStepLowerCase, true
+	case TransformFlagUpperCase:
+		return TransformStepUpperCase, true
+	case TransformFlagHash:
+		return TransformStepHash, true
+	}
+
+	return TransformStep{}, false
+}
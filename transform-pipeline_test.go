@@ -0,0 +1,111 @@
+package handy
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPipelineApply(t *testing.T) {
+	tcs := []struct {
+		summary        string
+		input          string
+		pipeline       Pipeline
+		expectedOutput string
+	}{
+		{"empty pipeline", " Handy ", NewPipeline(), " Handy "},
+		{"empty input", "", NewPipeline(TransformStepHash), ""},
+		{"trim and upper", "  handy go  ", NewPipeline(TransformStepTrim, TransformStepUpperCase), "HANDY GO"},
+		{"title case", "fRIEDRICH nietzsche", NewPipeline(TransformStepTitleCase), "Friedrich Nietzsche"},
+		{"only digits then truncate", "cpf: 123.456.789-09", NewPipeline(TransformStepOnlyDigits, TransformStepTruncate(3)), "123"},
+		{"truncate is rune aware", "çãôéü", NewPipeline(TransformStepTruncate(2)), "çã"},
+		{"replace", "a-b_c", NewPipeline(TransformStepReplace(map[string]string{"-": " ", "_": " "})), "a b c"},
+		{"replace overlapping keys", "abc aab", NewPipeline(TransformStepReplace(map[string]string{"a": "1", "ab": "2", "b": "3"})), "2c 12"},
+		{"custom func", "handy", NewPipeline(TransformStepFunc("reverse", Reverse)), "ydnah"},
+		{"hash", "Handy", NewPipeline(TransformStepHash), "e80649a6418b6c24fccb199dab7cb5bd6ec37593ea0285d52d717fcc7aee5fb3"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			tr := tc.pipeline.Apply(tc.input)
+
+			if tr != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %s, \n\tInput: %s, \n\tSteps: %v", tc.expectedOutput, tr, tc.input, tc.pipeline.Names())
+			}
+		})
+	}
+}
+
+func TestPipelineThen(t *testing.T) {
+	base := NewPipeline(TransformStepTrim)
+
+	upper := base.Then(TransformStepUpperCase)
+	lower := base.Then(TransformStepLowerCase)
+
+	if base.Len() != 1 || upper.Len() != 2 || lower.Len() != 2 {
+		t.Fatalf("Test has failed! Then() must not change the receiver. Lengths: %d, %d, %d", base.Len(), upper.Len(), lower.Len())
+	}
+
+	if upper.Apply(" Go ") != "GO" || lower.Apply(" Go ") != "go" {
+		t.Errorf("Test has failed! Got %s and %s", upper.Apply(" Go "), lower.Apply(" Go "))
+	}
+}
+
+func TestPipelineFlags(t *testing.T) {
+	tcs := []struct {
+		summary    string
+		pipeline   Pipeline
+		flags      uint
+		maxLen     int
+		compatible bool
+	}{
+		{"empty", NewPipeline(), TransformNone, 0, true},
+		{"from flags", PipelineFromFlags(20, TransformFlagTrim|TransformFlagHash), TransformFlagTrim | TransformFlagHash, 20, true},
+		{"smallest truncation", NewPipeline(TransformStepTruncate(10), TransformStepUpperCase, TransformStepTruncate(5)), TransformFlagUpperCase, 5, true},
+		{"no-op truncation", PipelineFromFlags(0, TransformNone), TransformNone, 0, true},
+		{"custom step named truncate", NewPipeline(TransformStepFunc("truncate", strings.TrimSpace)), TransformNone, 0, false},
+		{"custom step", NewPipeline(TransformStepLowerCase, TransformStepFunc("x", strings.TrimSpace)), TransformFlagLowerCase, 0, false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			flags, maxLen, ok := tc.pipeline.Flags()
+
+			if flags != tc.flags || maxLen != tc.maxLen || ok != tc.compatible {
+				t.Errorf("Test has failed!\n\tExpected: %d, %d, %t\n\tGot: %d, %d, %t", tc.flags, tc.maxLen, tc.compatible, flags, maxLen, ok)
+			}
+		})
+	}
+}
+
+func TestPipelineConcurrentUse(t *testing.T) {
+	p := NewPipeline(TransformStepTrim, TransformStepOnlyLetters, TransformStepUpperCase)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if s := p.Apply(" go 1.13 rocks "); s != "GOROCKS" {
+				t.Errorf("Test has failed! Got %s", s)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestNameFirstWith(t *testing.T) {
+	p := NewPipeline(TransformStepTrim, TransformStepTitleCase)
+
+	if s := NameFirstWith(" friedrich wilhelm nietzsche", p); s != "Friedrich" {
+		t.Errorf("Test has failed! Expected Friedrich, got %s", s)
+	}
+
+	if s := NameFirstAndLastWith("friedrich wilhelm nietzsche", p); s != "Friedrich Nietzsche" {
+		t.Errorf("Test has failed! Expected Friedrich Nietzsche, got %s", s)
+	}
+}
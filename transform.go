@@ -1,10 +1,5 @@
 package handy
 
-import (
-	"strings"
-	"unicode/utf8"
-)
-
 const (
 	// TransformNone No transformations are ordered. Only constraints maximum length
 	// TransformNone turns all other flags OFF.
@@ -27,82 +22,31 @@ const (
 	// TransformFlagHash After process all other flags, applies SHA256 hashing on string for output
 	// 	The routine applies handy.StringHash() on given string
 	TransformFlagHash = 128
-	// TransformFlagTitleCase Makes the string uppercase
+	// TransformFlagTitleCase Makes the first letter of each word uppercase, and the rest lowercase
 	// If case transformation flags are combined, the last one remains, considering the following order: TransformFlagTitleCase, TransformFlagLowerCase and TransformFlagUpperCase.
 	TransformFlagTitleCase = 256
 	// TransformFlagRemoveDigits Removes all digit characters, without to touch on any other
 	// If combined with TransformFlagOnlyLettersAndDigits, TransformFlagOnlyDigits or TransformFlagOnlyLetters, it's ineffective
 	TransformFlagRemoveDigits = 512
+	// TransformFlagNormalizeNFC Applies Unicode canonical composition (NFC) before any other transformation
+	TransformFlagNormalizeNFC = 1024
+	// TransformFlagNormalizeNFD Applies Unicode canonical decomposition (NFD) before any other transformation
+	TransformFlagNormalizeNFD = 2048
+	// TransformFlagNormalizeNFKC Applies Unicode compatibility composition (NFKC) before any other transformation
+	TransformFlagNormalizeNFKC = 4096
+	// TransformFlagRemoveAccents Strips diacritics, keeping the base letters. I.E: "José" becomes "Jose"
+	// Combined with TransformFlagHash, it makes accented and non-accented versions of a string hash the same
+	TransformFlagRemoveAccents = 8192
+	// TransformFlagASCIIFold Converts the string to its closest ASCII representation. I.E: "Straße" becomes "Strasse"
+	TransformFlagASCIIFold = 16384
 )
 
 // Transform handles a string according given flags/parametrization, as follows:
-// The transformations are made in arbitrary order, what can result in unexpected output. It the input matters, use TransformSerially instead.
+// The transformations are made in arbitrary order, what can result in unexpected output. It the input matters, use TransformSerially or a Pipeline instead.
 // If maxLen==0, truncation is skipped
 // The last operations are, by order, truncation and trimming.
 func Transform(s string, maxLen int, transformFlags uint) string {
-	if s == "" {
-		return s
-	}
-
-	if transformFlags&TransformNone == TransformNone {
-		if maxLen > 0 && utf8.RuneCountInString(s) > maxLen {
-			s = string([]rune(s)[:maxLen])
-		}
-
-		return s
-	}
-
-	if (transformFlags & TransformFlagOnlyLettersAndDigits) == TransformFlagOnlyLettersAndDigits {
-		s = OnlyLettersAndNumbers(s)
-	}
-
-	if (transformFlags & TransformFlagOnlyDigits) == TransformFlagOnlyDigits {
-		s = OnlyDigits(s)
-	}
-
-	if (transformFlags & TransformFlagOnlyLetters) == TransformFlagOnlyLetters {
-		s = OnlyLetters(s)
-	}
-
-	if (transformFlags & TransformFlagRemoveDigits) == TransformFlagRemoveDigits {
-		s = RemoveDigits(s)
-	}
-
-	// Have to trim before and after, to avoid issues with string truncation and new leading/trailing spaces
-	if (transformFlags & TransformFlagTrim) == TransformFlagTrim {
-		s = strings.TrimSpace(s)
-	}
-
-	if (transformFlags & TransformFlagTitleCase) == TransformFlagTitleCase {
-		s = strings.Title(strings.ToLower(s))
-	}
-
-	if (transformFlags & TransformFlagLowerCase) == TransformFlagLowerCase {
-		s = strings.ToLower(s)
-	}
-
-	if (transformFlags & TransformFlagUpperCase) == TransformFlagUpperCase {
-		s = strings.ToUpper(s)
-	}
-
-	if (transformFlags & TransformFlagHash) == TransformFlagHash {
-		s = StringHash(s)
-	}
-
-	if s == "" {
-		return s
-	}
-
-	if maxLen > 0 && utf8.RuneCountInString(s) > maxLen {
-		s = string([]rune(s)[:maxLen])
-	}
-
-	// Have to trim before and after, to avoid issues with string truncation and new leading/trailing spaces
-	if (transformFlags & TransformFlagTrim) == TransformFlagTrim {
-		s = strings.TrimSpace(s)
-	}
-
-	return s
+	return PipelineFromFlags(maxLen, transformFlags).Apply(s)
 }
 
 // TransformSerially reformat given string according parameters, in the order these params were sent
@@ -111,34 +55,5 @@ func Transform(s string, maxLen int, transformFlags uint) string {
 // If maxLen==0, truncation is skipped
 // Truncation is the last operation
 func TransformSerially(s string, maxLen int, transformFlags ...uint) string {
-	if s == "" {
-		return s
-	}
-
-	for _, flag := range transformFlags {
-		switch flag {
-		case TransformFlagOnlyLettersAndDigits:
-			s = OnlyLettersAndNumbers(s)
-		case TransformFlagOnlyDigits:
-			s = OnlyDigits(s)
-		case TransformFlagOnlyLetters:
-			s = OnlyLetters(s)
-		case TransformFlagTrim:
-			s = strings.TrimSpace(s)
-		case TransformFlagTitleCase:
-			s = strings.ToTitle(s)
-		case TransformFlagLowerCase:
-			s = strings.ToLower(s)
-		case TransformFlagUpperCase:
-			s = strings.ToUpper(s)
-		case TransformFlagHash:
-			s = StringHash(s)
-		}
-	}
-
-	if maxLen > 0 && utf8.RuneCountInString(s) > maxLen {
-		s = string([]rune(s)[:maxLen])
-	}
-
-	return s
+	return PipelineFromFlagsSerially(maxLen, transformFlags...).Apply(s)
 }
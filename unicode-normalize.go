@@ -0,0 +1,252 @@
+package handy
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:generate go run unicode-tables-gen.go
+
+// Hangul syllables are composed and decomposed algorithmically
+// See https://www.unicode.org/versions/Unicode14.0.0/ch03.pdf section 3.12
+const (
+	hangulSBase  = 0xAC00
+	hangulLBase  = 0x1100
+	hangulVBase  = 0x1161
+	hangulTBase  = 0x11A7
+	hangulLCount = 19
+	hangulVCount = 21
+	hangulTCount = 28
+	hangulNCount = hangulVCount * hangulTCount
+	hangulSCount = hangulLCount * hangulNCount
+)
+
+// asciiFoldExtra holds foldings for runes that have no decomposition at all, like ß or æ
+var asciiFoldExtra = map[rune]string{
+	'ß': "ss", 'ẞ': "SS", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+	'ø': "o", 'Ø': "O", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D",
+	'ł': "l", 'Ł': "L", 'þ': "th", 'Þ': "Th", 'ı': "i", 'ħ': "h",
+	'Ħ': "H", 'ŧ': "t", 'Ŧ': "T", 'ŋ': "n", 'Ŋ': "N", 'ĸ': "k",
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '“': `"`, '”': `"`,
+	'„': `"`, '«': `"`, '»': `"`, '‐': "-", '‑': "-", '‒': "-",
+	'–': "-", '—': "-", '―': "-", '−': "-",
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decomposeRune(r rune, compat bool, buffer []rune) []rune {
+	if r >= hangulSBase && r < hangulSBase+hangulSCount {
+		si := r - hangulSBase
+
+		buffer = append(buffer, hangulLBase+si/hangulNCount, hangulVBase+(si%hangulNCount)/hangulTCount)
+
+		if t := si % hangulTCount; t != 0 {
+			buffer = append(buffer, hangulTBase+t)
+		}
+
+		return buffer
+	}
+
+	if compat {
+		if d, ok := unicodeCompatibilityDecomposition[r]; ok {
+			return append(buffer, []rune(d)...)
+		}
+	}
+
+	if d, ok := unicodeCanonicalDecomposition[r]; ok {
+		return append(buffer, []rune(d)...)
+	}
+
+	return append(buffer, r)
+}
+
+// decompose returns the fully decomposed runes, in canonical order
+func decompose(s string, compat bool) []rune {
+	rs := make([]rune, 0, len(s))
+
+	for _, r := range s {
+		rs = decomposeRune(r, compat, rs)
+	}
+
+	// Canonical ordering: a stable sort of each sequence of combining marks by its combining class
+	for i := 1; i < len(rs); i++ {
+		cc := unicodeCombiningClass[rs[i]]
+
+		if cc == 0 {
+			continue
+		}
+
+		for j := i; j > 0; j-- {
+			prev := unicodeCombiningClass[rs[j-1]]
+
+			if prev == 0 || prev <= cc {
+				break
+			}
+
+			rs[j-1], rs[j] = rs[j], rs[j-1]
+		}
+	}
+
+	return rs
+}
+
+func composePair(a, b rune) (rune, bool) {
+	// Hangul LV
+	if a >= hangulLBase && a < hangulLBase+hangulLCount && b >= hangulVBase && b < hangulVBase+hangulVCount {
+		return hangulSBase + ((a-hangulLBase)*hangulVCount+(b-hangulVBase))*hangulTCount, true
+	}
+
+	// Hangul LV + T
+	if a >= hangulSBase && a < hangulSBase+hangulSCount && (a-hangulSBase)%hangulTCount == 0 && b > hangulTBase && b < hangulTBase+hangulTCount {
+		return a + (b - hangulTBase), true
+	}
+
+	r, ok := unicodeComposition[uint64(a)<<32|uint64(b)]
+
+	return r, ok
+}
+
+// compose applies canonical composition over already decomposed and ordered runes
+func compose(rs []rune) []rune {
+	if len(rs) < 2 {
+		return rs
+	}
+
+	starterPos := 0
+	starter := rs[0]
+	lastClass := int(unicodeCombiningClass[starter])
+
+	if lastClass != 0 {
+		// The sequence doesn't begin with a starter, so nothing can be composed until one shows up
+		lastClass = 256
+	}
+
+	compPos := 1
+
+	for i := 1; i < len(rs); i++ {
+		r := rs[i]
+		cc := int(unicodeCombiningClass[r])
+
+		if composite, ok := composePair(starter, r); ok && (lastClass < cc || lastClass == 0) {
+			rs[starterPos] = composite
+			starter = composite
+			continue
+		}
+
+		if cc == 0 {
+			starterPos = compPos
+			starter = r
+		}
+
+		lastClass = cc
+		rs[compPos] = r
+		compPos++
+	}
+
+	return rs[:compPos]
+}
+
+// NormalizeNFD returns the given string in Unicode Normalization Form D (canonical decomposition)
+// Example: NormalizeNFD("ç") returns "c\u0327", a "c" followed by the combining cedilla
+func NormalizeNFD(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	return string(decompose(s, false))
+}
+
+// NormalizeNFC returns the given string in Unicode Normalization Form C (canonical decomposition followed by canonical composition)
+// That's the form most keyboards and databases produce, and the one to use before comparing or hashing strings
+func NormalizeNFC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	return string(compose(decompose(s, false)))
+}
+
+// NormalizeNFKD returns the given string in Unicode Normalization Form KD (compatibility decomposition)
+// Example: NormalizeNFKD("㎏") returns "kg"
+func NormalizeNFKD(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	return string(decompose(s, true))
+}
+
+// NormalizeNFKC returns the given string in Unicode Normalization Form KC (compatibility decomposition followed by canonical composition)
+// Example: NormalizeNFKC("ﬁｎａｌ") returns "final"
+func NormalizeNFKC(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	return string(compose(decompose(s, true)))
+}
+
+// RemoveAccents strips diacritics (non-spacing marks) from the given string, keeping the base letters
+// The result is returned in NFC form. Letters without decomposition, like ß or ø, are kept. Use ASCIIFold for those.
+// Example: RemoveAccents("José Conceição") returns "Jose Conceicao"
+func RemoveAccents(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	rs := decompose(s, false)
+
+	j := 0
+
+	for _, r := range rs {
+		if !unicode.Is(unicode.Mn, r) {
+			rs[j] = r
+			j++
+		}
+	}
+
+	return string(compose(rs[:j]))
+}
+
+// ASCIIFold converts the given string to its closest ASCII representation
+// It applies compatibility decomposition, strips diacritics and folds special letters and punctuation, like ß→ss, æ→ae and “→"
+// Runes that have no ASCII equivalent are kept unchanged
+// Example: ASCIIFold("Straße Ærø ﬁ ＡＢＣ") returns "Strasse AEro fi ABC"
+func ASCIIFold(s string) string {
+	if isASCII(s) {
+		return s
+	}
+
+	rs := decompose(s, true)
+
+	folded := make([]rune, 0, len(rs))
+
+	for _, r := range rs {
+		if r < utf8.RuneSelf {
+			folded = append(folded, r)
+			continue
+		}
+
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if f, ok := asciiFoldExtra[r]; ok {
+			folded = append(folded, []rune(f)...)
+			continue
+		}
+
+		folded = append(folded, r)
+	}
+
+	// Whatever was kept out of ASCII goes back to its composed form
+	return string(compose(folded))
+}
@@ -0,0 +1,92 @@
+package handy
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tcs := []struct {
+		summary string
+		input   string
+		nfc     string
+		nfd     string
+		nfkc    string
+	}{
+		{"ascii", "Handy", "Handy", "Handy", "Handy"},
+		{"composed", "Jos\u00e9", "Jos\u00e9", "Jose\u0301", "Jos\u00e9"},
+		{"decomposed", "Jose\u0301", "Jos\u00e9", "Jose\u0301", "Jos\u00e9"},
+		{"cedilla and tilde", "c\u0327a\u0303o", "\u00e7\u00e3o", "c\u0327a\u0303o", "\u00e7\u00e3o"},
+		{"marks reordering", "a\u0301\u0323", "\u1ea1\u0301", "a\u0323\u0301", "\u1ea1\u0301"},
+		{"vietnamese", "\u1ec7", "\u1ec7", "e\u0323\u0302", "\u1ec7"},
+		{"ligature", "ﬁnal", "ﬁnal", "ﬁnal", "final"},
+		{"full-width", "ＡＢＣ１", "ＡＢＣ１", "ＡＢＣ１", "ABC1"},
+		{"angstrom singleton", "\u212b", "\u00c5", "A\u030a", "\u00c5"},
+		{"hangul", "\ud55c", "\ud55c", "\u1112\u1161\u11ab", "\ud55c"},
+		{"squared unit", "㎏", "㎏", "㎏", "kg"},
+		{"mathematical letter", "𝐀𝟏", "𝐀𝟏", "𝐀𝟏", "A1"},
+		{"arabic ligature", "\ufdfa", "\ufdfa", "\ufdfa", "\u0635\u0644\u0649 \u0627\u0644\u0644\u0647 \u0639\u0644\u064a\u0647 \u0648\u0633\u0644\u0645"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if r := NormalizeNFC(tc.input); r != tc.nfc {
+				t.Errorf("Test has failed!\n\tNFC Expected: %+q, \n\tGot: %+q", tc.nfc, r)
+			}
+
+			if r := NormalizeNFD(tc.input); r != tc.nfd {
+				t.Errorf("Test has failed!\n\tNFD Expected: %+q, \n\tGot: %+q", tc.nfd, r)
+			}
+
+			if r := NormalizeNFKC(tc.input); r != tc.nfkc {
+				t.Errorf("Test has failed!\n\tNFKC Expected: %+q, \n\tGot: %+q", tc.nfkc, r)
+			}
+		})
+	}
+}
+
+func TestRemoveAccents(t *testing.T) {
+	tcs := []defaultTestStruct{
+		{"empty", "", ""},
+		{"ascii", "Jose", "Jose"},
+		{"portuguese", "José Conceição Açaí Pão", "Jose Conceicao Acai Pao"},
+		{"decomposed input", "Jose\u0301", "Jose"},
+		{"no decomposition kept", "Straße Øre", "Straße Øre"},
+		{"greek", "άλφα", "αλφα"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if r := RemoveAccents(tc.input.(string)); r != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s,\n\tExpected: %s, \n\tGot: %s", tc.input, tc.expectedOutput, r)
+			}
+		})
+	}
+}
+
+func TestASCIIFold(t *testing.T) {
+	tcs := []defaultTestStruct{
+		{"empty", "", ""},
+		{"portuguese", "Ação à São João", "Acao a Sao Joao"},
+		{"german", "Straße Ärger", "Strasse Arger"},
+		{"nordic", "Ærø Łódź", "AEro Lodz"},
+		{"ligatures", "ﬁﬂ œuvre", "fifl oeuvre"},
+		{"full-width", "ＡＢＣ１２３", "ABC123"},
+		{"quotes and dashes", "“handy” – ‘go’", `"handy" - 'go'`},
+		{"non-latin kept", "日本 한국", "日本 한국"},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			if r := ASCIIFold(tc.input.(string)); r != tc.expectedOutput {
+				t.Errorf("Test has failed!\n\tInput: %s,\n\tExpected: %s, \n\tGot: %s", tc.input, tc.expectedOutput, r)
+			}
+		})
+	}
+}
+
+func TestTransformRemoveAccentsHash(t *testing.T) {
+	a := Transform("José", 0, TransformFlagRemoveAccents|TransformFlagLowerCase|TransformFlagHash)
+	b := Transform("Jose", 0, TransformFlagRemoveAccents|TransformFlagLowerCase|TransformFlagHash)
+
+	if a != b {
+		t.Errorf("Test has failed! Hashes differ: %s and %s", a, b)
+	}
+}
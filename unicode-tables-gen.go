@@ -0,0 +1,294 @@
+//go:build ignore
+// +build ignore
+
+// This program generates unicode-tables.go from the Unicode Character Database.
+// Run it with go generate, or: go run unicode-tables-gen.go -ucd https://www.unicode.org/Public/14.0.0/ucd/
+// The -ucd flag also accepts a local directory holding UnicodeData.txt and CompositionExclusions.txt.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const unicodeVersion = "14.0.0"
+
+var (
+	ucd    = flag.String("ucd", "https://www.unicode.org/Public/"+unicodeVersion+"/ucd/", "URL or directory of the Unicode Character Database")
+	output = flag.String("output", "unicode-tables.go", "file to write")
+)
+
+type char struct {
+	ccc    uint8
+	compat bool
+	decomp []rune
+}
+
+func main() {
+	flag.Parse()
+
+	chars := map[rune]char{}
+
+	err := eachLine("UnicodeData.txt", func(fields []string) error {
+		r, err := parseRune(fields[0])
+
+		if err != nil {
+			return err
+		}
+
+		ccc, err := strconv.ParseUint(fields[3], 10, 8)
+
+		if err != nil {
+			return err
+		}
+
+		c := char{ccc: uint8(ccc)}
+
+		decomp := strings.Fields(fields[5])
+
+		if len(decomp) > 0 && strings.HasPrefix(decomp[0], "<") {
+			c.compat = true
+			decomp = decomp[1:]
+		}
+
+		for _, s := range decomp {
+			d, err := parseRune(s)
+
+			if err != nil {
+				return err
+			}
+
+			c.decomp = append(c.decomp, d)
+		}
+
+		chars[r] = c
+
+		return nil
+	})
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	excluded := map[rune]bool{}
+
+	err = eachLine("CompositionExclusions.txt", func(fields []string) error {
+		r, err := parseRune(fields[0])
+
+		if err != nil {
+			return err
+		}
+
+		excluded[r] = true
+
+		return nil
+	})
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	runes := make([]rune, 0, len(chars))
+
+	for r := range chars {
+		runes = append(runes, r)
+	}
+
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+	var (
+		canonical, compatibility, combining, composition []string
+		pairs                                            [][3]rune
+	)
+
+	for _, r := range runes {
+		c := chars[r]
+
+		nfd := decompose(chars, r, false)
+		nfkd := decompose(chars, r, true)
+
+		if string(nfd) != string(r) {
+			canonical = append(canonical, fmt.Sprintf("0x%04X: %s", r, quote(nfd)))
+		}
+
+		if string(nfkd) != string(nfd) {
+			compatibility = append(compatibility, fmt.Sprintf("0x%04X: %s", r, quote(nfkd)))
+		}
+
+		if c.ccc != 0 {
+			combining = append(combining, fmt.Sprintf("0x%04X: %d", r, c.ccc))
+		}
+
+		// primary composites: canonical pairs, starting with a starter, that aren't excluded
+		if !c.compat && len(c.decomp) == 2 && c.ccc == 0 && chars[c.decomp[0]].ccc == 0 && !excluded[r] {
+			pairs = append(pairs, [3]rune{c.decomp[0], c.decomp[1], r})
+		}
+	}
+
+	// the pairs are sorted by their key, like the other tables by rune
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0] || (pairs[i][0] == pairs[j][0] && pairs[i][1] < pairs[j][1])
+	})
+
+	for _, p := range pairs {
+		composition = append(composition, fmt.Sprintf("0x%04X<<32 | 0x%04X: 0x%04X", p[0], p[1], p[2]))
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by unicode-tables-gen.go from the Unicode Character Database %s. DO NOT EDIT.\n\n", unicodeVersion)
+	fmt.Fprintf(&b, "package handy\n\n")
+	fmt.Fprintf(&b, "// unicodeCanonicalDecomposition maps runes to their full canonical decomposition (NFD)\n")
+	fmt.Fprintf(&b, "// Hangul syllables are decomposed algorithmically and are not listed here\n")
+	writeMap(&b, "unicodeCanonicalDecomposition", "map[rune]string", canonical, 4)
+	fmt.Fprintf(&b, "// unicodeCompatibilityDecomposition maps runes to their full compatibility decomposition (NFKD), when it differs from the canonical one\n")
+	writeMap(&b, "unicodeCompatibilityDecomposition", "map[rune]string", compatibility, 4)
+	fmt.Fprintf(&b, "// unicodeCombiningClass holds the canonical combining class of every rune where it is not zero\n")
+	writeMap(&b, "unicodeCombiningClass", "map[rune]uint8", combining, 8)
+	fmt.Fprintf(&b, "// unicodeComposition maps canonical pairs (starter<<32 | combining) to their primary composite\n")
+	writeMap(&b, "unicodeComposition", "map[uint64]rune", composition, 4)
+
+	src, err := format.Source(b.Bytes())
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// eachLine calls fn with the fields of each data line of a UCD file, skipping comments
+func eachLine(name string, fn func(fields []string) error) error {
+	var r io.ReadCloser
+
+	if strings.HasPrefix(*ucd, "http://") || strings.HasPrefix(*ucd, "https://") {
+		resp, err := http.Get(strings.TrimSuffix(*ucd, "/") + "/" + name)
+
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return fmt.Errorf("%s: %s", name, resp.Status)
+		}
+
+		r = resp.Body
+	} else {
+		f, err := os.Open(filepath.Join(*ucd, name))
+
+		if err != nil {
+			return err
+		}
+
+		r = f
+	}
+
+	defer r.Close()
+
+	s := bufio.NewScanner(r)
+
+	for s.Scan() {
+		line := s.Text()
+
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ";")
+
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		if err := fn(fields); err != nil {
+			return fmt.Errorf("%s: %q: %v", name, line, err)
+		}
+	}
+
+	return s.Err()
+}
+
+func parseRune(s string) (rune, error) {
+	n, err := strconv.ParseUint(s, 16, 32)
+
+	return rune(n), err
+}
+
+// decompose returns the full decomposition of r, applying compatibility mappings too when compat is true
+// Canonical ordering isn't needed, as the decompositions in the database are already ordered.
+func decompose(chars map[rune]char, r rune, compat bool) []rune {
+	c := chars[r]
+
+	if len(c.decomp) == 0 || (c.compat && !compat) {
+		return []rune{r}
+	}
+
+	var rs []rune
+
+	for _, d := range c.decomp {
+		rs = append(rs, decompose(chars, d, compat)...)
+	}
+
+	return rs
+}
+
+// quote writes runes as a Go string, escaping all but printable ASCII
+func quote(rs []rune) string {
+	var sb strings.Builder
+
+	sb.WriteByte('"')
+
+	for _, r := range rs {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= 0x20 && r < 0x7F:
+			sb.WriteRune(r)
+		case r <= 0xFFFF:
+			fmt.Fprintf(&sb, `\u%04X`, r)
+		default:
+			fmt.Fprintf(&sb, `\U%08X`, r)
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+func writeMap(b *bytes.Buffer, name, typ string, entries []string, perLine int) {
+	fmt.Fprintf(b, "var %s = %s{\n", name, typ)
+
+	for i := 0; i < len(entries); i += perLine {
+		end := i + perLine
+
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		fmt.Fprintf(b, "\t%s,\n", strings.Join(entries[i:end], ", "))
+	}
+
+	fmt.Fprintf(b, "}\n\n")
+}
@@ -0,0 +1,721 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidationTag is the struct tag read by Validate()
+// Example: `handy:"required,email,maxlen=80"`
+const ValidationTag = "handy"
+
+// ValidationField is what a ValidationRule receives to decide if a value is valid
+type ValidationField struct {
+	// Path is the field path, like "Address.Street" or "Phones[2]"
+	Path string
+	// Value is the field value, with pointers already dereferenced
+	Value reflect.Value
+	// Param is the text following "=" in the rule, like "80" in "maxlen=80"
+	Param string
+	// Params holds all the rules declared in the field tag, with their params, for rules that depend on others
+	// Example: agemin reads Params["date"] to know how to parse a string date
+	Params map[string]string
+}
+
+// String returns the field value as string, or "" if it's not a string
+func (f ValidationField) String() string {
+	if f.Value.IsValid() && f.Value.Kind() == reflect.String {
+		return f.Value.String()
+	}
+
+	return ""
+}
+
+// ValidationRule checks a field, returning nil when it's valid
+// The returned error is kept in ValidationError.Err, and its text is used as message for custom rules.
+// Errors wrapping ErrValidationParam are not about the value, but about the tag, and make Validate() fail with them as they are.
+type ValidationRule func(f ValidationField) error
+
+// ErrValidationParam is wrapped by the errors of rules that can't understand their parameter, like "maxlen=abc"
+var ErrValidationParam = errors.New("handy: invalid validation rule parameter")
+
+// ValidationError describes a field that broke a rule
+type ValidationError struct {
+	// Field is the path of the invalid field. JSON names are used when the field has a json tag
+	Field string
+	// Rule is the name of the broken rule, like "required" or "maxlen"
+	Rule string
+	// Param is the rule parameter, like "80" in "maxlen=80"
+	Param string
+	// Err is the error returned by the rule
+	Err error
+}
+
+// Error returns the field path and the english message
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message(""))
+}
+
+// Message returns a meaningful message describing the error, considering the given idiom. The fallback is in english
+func (e ValidationError) Message(idiom string) string {
+	return validationErrorMessage(idiom, e)
+}
+
+// Unwrap returns the error returned by the rule, making errors.Is() work with sentinels like ErrCheckStrNumbersDenied
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is the list of all the validation errors found by Validate()
+type ValidationErrors []ValidationError
+
+// Error returns all the errors, in english, separated by semicolons
+func (e ValidationErrors) Error() string {
+	a := make([]string, len(e))
+
+	for i, v := range e {
+		a[i] = v.Error()
+	}
+
+	return strings.Join(a, "; ")
+}
+
+// Messages returns the error messages indexed by field path, considering the given idiom
+func (e ValidationErrors) Messages(idiom string) map[string]string {
+	m := make(map[string]string, len(e))
+
+	for _, v := range e {
+		m[v.Field] = v.Message(idiom)
+	}
+
+	return m
+}
+
+// Field returns the error for the given field path, if there's one
+func (e ValidationErrors) Field(path string) (ValidationError, bool) {
+	for _, v := range e {
+		if v.Field == path {
+			return v, true
+		}
+	}
+
+	return ValidationError{}, false
+}
+
+var (
+	validationRules   = map[string]ValidationRule{}
+	validationRulesMu sync.RWMutex
+)
+
+// ValidationRuleRegister adds a custom rule, or replaces a built-in one, to be used in `handy` struct tags
+// Example: ValidationRuleRegister("even", func(f ValidationField) error { if f.Value.Int()%2 != 0 { return errors.New("must be even") }; return nil })
+func ValidationRuleRegister(name string, rule ValidationRule) {
+	validationRulesMu.Lock()
+	defer validationRulesMu.Unlock()
+
+	validationRules[name] = rule
+}
+
+func validationRule(name string) (ValidationRule, bool) {
+	validationRulesMu.RLock()
+	defer validationRulesMu.RUnlock()
+
+	rule, ok := validationRules[name]
+
+	return rule, ok
+}
+
+// Validate checks a struct, or a pointer to a struct, according the `handy` tags of its fields
+// Nested structs, pointers and slices of structs are visited recursively. Rules placed after "dive" apply to each slice element.
+// Fields of embedded structs are promoted, as encoding/json does, even when the embedded type is unexported.
+// Empty fields are only checked by "required"; all other rules are skipped for them.
+// For each field, only the first broken rule is reported.
+// It returns nil when everything is alright, ValidationErrors when some field is invalid,
+// or a plain error when the tags can't be understood, like unknown rules, or bad parameters wrapping ErrValidationParam.
+// Built-in rules: required, email, cpf, cnpj, phone, personname, minlen, maxlen, min, max, oneof, checkstr, date, agemin and agemax
+// agemin and agemax report values that are not dates under the "date" rule, even when the field has no "date" rule.
+func Validate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return errors.New("handy: Validate() requires a non-nil struct")
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("handy: Validate() requires a struct, got %s", rv.Kind())
+	}
+
+	var errs ValidationErrors
+
+	if err := validateStruct(rv, "", &errs); err != nil {
+		return err
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+type validationTagRule struct {
+	name  string
+	param string
+}
+
+func validationParseTag(tag string) (rules, dive []validationTagRule) {
+	target := &rules
+
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+
+		if item == "" {
+			continue
+		}
+
+		if item == "dive" {
+			target = &dive
+			continue
+		}
+
+		r := validationTagRule{name: item}
+
+		if i := strings.Index(item, "="); i >= 0 {
+			r.name, r.param = item[:i], item[i+1:]
+		}
+
+		*target = append(*target, r)
+	}
+
+	return rules, dive
+}
+
+func validationFieldName(sf reflect.StructField) string {
+	if j := sf.Tag.Get("json"); j != "" && j != "-" {
+		if name := strings.Split(j, ",")[0]; name != "" {
+			return name
+		}
+	}
+
+	return sf.Name
+}
+
+func validateStruct(rv reflect.Value, prefix string, errs *ValidationErrors) error {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get(ValidationTag)
+
+		if tag == "-" {
+			continue
+		}
+
+		path := prefix + validationFieldName(sf)
+
+		rules, dive := validationParseTag(tag)
+
+		// Embedded structs without a json name have their fields promoted, even when the struct type is unexported,
+		// as encoding/json does. So their fields are named without the struct name.
+		if validationIsPromoted(sf) {
+			fv := rv.Field(i)
+
+			if sf.PkgPath == "" {
+				ok, err := validateRules(fv, path, rules, errs)
+
+				if err != nil || !ok {
+					return err
+				}
+			}
+
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+
+				fv = fv.Elem()
+			}
+
+			if err := validateStruct(fv, prefix, errs); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// Unexported fields are skipped
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if err := validateValue(rv.Field(i), path, rules, dive, errs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// validationIsPromoted tells if the field is an embedded struct, or pointer to struct, whose fields are promoted
+func validationIsPromoted(sf reflect.StructField) bool {
+	if !sf.Anonymous {
+		return false
+	}
+
+	if j := sf.Tag.Get("json"); j != "" && strings.Split(j, ",")[0] != "" {
+		return false
+	}
+
+	t := sf.Type
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+func validateValue(fv reflect.Value, path string, rules, dive []validationTagRule, errs *ValidationErrors) error {
+	ok, err := validateRules(fv, path, rules, errs)
+
+	if err != nil || !ok {
+		return err
+	}
+
+	for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil
+		}
+
+		fv = fv.Elem()
+	}
+
+	switch {
+	case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+		return validateStruct(fv, path+".", errs)
+	case (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && (len(dive) > 0 || validationHasStructs(fv.Type().Elem())):
+		for i := 0; i < fv.Len(); i++ {
+			if err := validateValue(fv.Index(i), fmt.Sprintf("%s[%d]", path, i), dive, nil, errs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validationHasStructs(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return (t.Kind() == reflect.Struct && t != timeType) || t.Kind() == reflect.Interface
+}
+
+// validateRules runs the given rules over the value, stopping at the first broken one
+// It returns false when some rule was broken
+func validateRules(fv reflect.Value, path string, rules []validationTagRule, errs *ValidationErrors) (bool, error) {
+	if len(rules) == 0 {
+		return true, nil
+	}
+
+	params := make(map[string]string, len(rules))
+
+	for _, r := range rules {
+		params[r.name] = r.param
+	}
+
+	empty := validationIsEmpty(fv)
+
+	for fv.Kind() == reflect.Ptr && !fv.IsNil() {
+		fv = fv.Elem()
+	}
+
+	for _, r := range rules {
+		if empty && r.name != "required" {
+			continue
+		}
+
+		rule, found := validationRule(r.name)
+
+		if !found {
+			return false, fmt.Errorf("handy: unknown validation rule %q on field %s", r.name, path)
+		}
+
+		if err := rule(ValidationField{Path: path, Value: fv, Param: r.param, Params: params}); err != nil {
+			if errors.Is(err, ErrValidationParam) {
+				return false, err
+			}
+
+			e := ValidationError{Field: path, Rule: r.name, Param: r.param, Err: err}
+
+			var other validationOtherRuleError
+
+			if errors.As(err, &other) {
+				e.Rule, e.Param, e.Err = other.rule, params[other.rule], other.err
+			}
+
+			*errs = append(*errs, e)
+
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func validationIsEmpty(fv reflect.Value) bool {
+	switch fv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Interface:
+		return fv.IsNil()
+	case reflect.String:
+		return strings.TrimSpace(fv.String()) == ""
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return fv.Len() == 0
+	case reflect.Struct:
+		if fv.Type() == timeType {
+			return fv.Interface().(time.Time).IsZero()
+		}
+
+		return false
+	}
+
+	return fv.IsZero()
+}
+
+// errValidation* are returned by built-in rules, and translated by validationErrorMessage
+var (
+	errValidationRequired = errors.New("required")
+	errValidationInvalid  = errors.New("invalid")
+	errValidationRange    = errors.New("out of range")
+	errValidationType     = errors.New("unsupported type")
+)
+
+// validationPersonNameError carries the CheckPersonName result code
+type validationPersonNameError uint8
+
+func (e validationPersonNameError) Error() string {
+	return CheckPersonNameResult("", uint8(e))
+}
+
+// validationDateError carries the DateStrCheck result code
+type validationDateError DateStrCheck
+
+func (e validationDateError) Error() string {
+	return DateStrCheckErrMessage("", DateStrCheck(e))
+}
+
+// validationOtherRuleError reports a failure under another rule, like agemin does with dates that can't be parsed
+type validationOtherRuleError struct {
+	rule string
+	err  error
+}
+
+func (e validationOtherRuleError) Error() string {
+	return e.err.Error()
+}
+
+func (e validationOtherRuleError) Unwrap() error {
+	return e.err
+}
+
+func validationParamError(f ValidationField) error {
+	return fmt.Errorf("%w %q on field %s", ErrValidationParam, f.Param, f.Path)
+}
+
+func validationIntParam(f ValidationField) (int64, error) {
+	i, err := strconv.ParseInt(f.Param, 10, 64)
+
+	if err != nil {
+		return 0, validationParamError(f)
+	}
+
+	return i, nil
+}
+
+func validationStringRule(check func(s string) bool) ValidationRule {
+	return func(f ValidationField) error {
+		if f.Value.Kind() != reflect.String {
+			return errValidationType
+		}
+
+		if !check(f.Value.String()) {
+			return errValidationInvalid
+		}
+
+		return nil
+	}
+}
+
+func validationLength(f ValidationField) (int, error) {
+	switch f.Value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(f.Value.String()), nil
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return f.Value.Len(), nil
+	}
+
+	return 0, errValidationType
+}
+
+func validationNumber(f ValidationField) (float64, error) {
+	switch f.Value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(f.Value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(f.Value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return f.Value.Float(), nil
+	}
+
+	return 0, errValidationType
+}
+
+// validationDate returns the field as time, parsing strings with the format given by the "date" rule
+func validationDate(f ValidationField) (time.Time, error) {
+	if f.Value.Type() == timeType {
+		return f.Value.Interface().(time.Time), nil
+	}
+
+	if f.Value.Kind() != reflect.String {
+		return time.Time{}, errValidationType
+	}
+
+	format, ok := f.Params["date"]
+
+	if !ok || format == "" {
+		format = "yyyy-mm-dd"
+	}
+
+	dt := StringAsDateTime(f.Value.String(), format)
+
+	if dt.IsZero() {
+		return dt, validationDateError(DateStrCheckErrInvalid)
+	}
+
+	return dt, nil
+}
+
+var checkStrFlagNames = map[string]uint64{
+	"allowempty":             CheckStrAllowEmpty,
+	"denyspaces":             CheckStrDenySpaces,
+	"denynumbers":            CheckStrDenyNumbers,
+	"denyletters":            CheckStrDenyLetters,
+	"denysymbols":            CheckStrDenySymbols,
+	"denymorethanoneword":    CheckStrDenyMoreThanOneWord,
+	"denyuppercase":          CheckStrDenyUpperCase,
+	"denylowercase":          CheckStrDenyLowercase,
+	"denyunicode":            CheckStrDenyUnicode,
+	"requirenumbers":         CheckStrRequireNumbers,
+	"requireletters":         CheckStrRequireLetters,
+	"requiresymbols":         CheckStrRequireSymbols,
+	"requiremorethanoneword": CheckStrRequireMoreThanOneWord,
+	"requireuppercase":       CheckStrRequireUpperCase,
+	"requirelowercase":       CheckStrRequireLowercase,
+}
+
+func init() {
+	validationRules["required"] = func(f ValidationField) error {
+		if validationIsEmpty(f.Value) {
+			return errValidationRequired
+		}
+
+		return nil
+	}
+
+	validationRules["email"] = validationStringRule(CheckEmail)
+	validationRules["cpf"] = validationStringRule(CheckCPF)
+	validationRules["cnpj"] = validationStringRule(CheckCNPJ)
+	validationRules["phone"] = validationStringRule(func(s string) bool {
+		return CheckPhone(s, false)
+	})
+
+	validationRules["personname"] = func(f ValidationField) error {
+		if f.Value.Kind() != reflect.String {
+			return errValidationType
+		}
+
+		if r := CheckPersonName(f.Value.String(), false); r != CheckPersonNameResultOK {
+			return validationPersonNameError(r)
+		}
+
+		return nil
+	}
+
+	validationRules["minlen"] = func(f ValidationField) error {
+		n, err := validationIntParam(f)
+
+		if err != nil {
+			return err
+		}
+
+		l, err := validationLength(f)
+
+		if err != nil {
+			return err
+		}
+
+		if int64(l) < n {
+			return errValidationRange
+		}
+
+		return nil
+	}
+
+	validationRules["maxlen"] = func(f ValidationField) error {
+		n, err := validationIntParam(f)
+
+		if err != nil {
+			return err
+		}
+
+		l, err := validationLength(f)
+
+		if err != nil {
+			return err
+		}
+
+		if int64(l) > n {
+			return errValidationRange
+		}
+
+		return nil
+	}
+
+	validationRules["min"] = func(f ValidationField) error {
+		limit, err := strconv.ParseFloat(f.Param, 64)
+
+		if err != nil {
+			return validationParamError(f)
+		}
+
+		n, err := validationNumber(f)
+
+		if err != nil {
+			return err
+		}
+
+		if n < limit {
+			return errValidationRange
+		}
+
+		return nil
+	}
+
+	validationRules["max"] = func(f ValidationField) error {
+		limit, err := strconv.ParseFloat(f.Param, 64)
+
+		if err != nil {
+			return validationParamError(f)
+		}
+
+		n, err := validationNumber(f)
+
+		if err != nil {
+			return err
+		}
+
+		if n > limit {
+			return errValidationRange
+		}
+
+		return nil
+	}
+
+	// oneof=a|b|c
+	validationRules["oneof"] = func(f ValidationField) error {
+		s := fmt.Sprint(f.Value.Interface())
+
+		for _, option := range strings.Split(f.Param, "|") {
+			if s == option {
+				return nil
+			}
+		}
+
+		return errValidationInvalid
+	}
+
+	// checkstr=denyspaces|requirenumbers, considering the minlen and maxlen rules of the same field
+	validationRules["checkstr"] = func(f ValidationField) error {
+		if f.Value.Kind() != reflect.String {
+			return errValidationType
+		}
+
+		var flags uint64
+
+		for _, name := range strings.Split(f.Param, "|") {
+			flag, ok := checkStrFlagNames[strings.ToLower(strings.TrimSpace(name))]
+
+			if !ok {
+				return fmt.Errorf("%w: unknown checkstr flag %q on field %s", ErrValidationParam, name, f.Path)
+			}
+
+			flags |= flag
+		}
+
+		minLen, _ := strconv.ParseUint(f.Params["minlen"], 10, 64)
+		maxLen, _ := strconv.ParseUint(f.Params["maxlen"], 10, 64)
+
+		return CheckStrAll(f.Value.String(), uint(minLen), uint(maxLen), flags)
+	}
+
+	validationRules["date"] = func(f ValidationField) error {
+		_, err := validationDate(f)
+
+		return err
+	}
+
+	validationRules["agemin"] = func(f ValidationField) error {
+		n, err := validationIntParam(f)
+
+		if err != nil {
+			return err
+		}
+
+		dt, err := validationDate(f)
+
+		if err != nil {
+			return validationOtherRuleError{"date", err}
+		}
+
+		if int64(YearsAge(dt)) < n {
+			return validationDateError(DateStrCheckErrOutOfRange)
+		}
+
+		return nil
+	}
+
+	validationRules["agemax"] = func(f ValidationField) error {
+		n, err := validationIntParam(f)
+
+		if err != nil {
+			return err
+		}
+
+		dt, err := validationDate(f)
+
+		if err != nil {
+			return validationOtherRuleError{"date", err}
+		}
+
+		if int64(YearsAge(dt)) > n {
+			return validationDateError(DateStrCheckErrOutOfRange)
+		}
+
+		return nil
+	}
+}
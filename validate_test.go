@@ -0,0 +1,229 @@
+package handy
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type validateTestAddress struct {
+	Street string `json:"street" handy:"required,maxlen=10"`
+	Zip    string `handy:"minlen=8"`
+}
+
+type validateTestPerson struct {
+	Name      string                `json:"name" handy:"required,personname"`
+	Email     string                `json:"email" handy:"required,email,maxlen=80"`
+	CPF       string                `handy:"cpf"`
+	Birthdate string                `handy:"date=dd/mm/yyyy,agemin=18"`
+	Age       int                   `handy:"min=0,max=130"`
+	Plan      string                `handy:"oneof=free|pro"`
+	Nick      string                `handy:"checkstr=denyspaces|requirenumbers,minlen=3"`
+	Address   validateTestAddress   `json:"address"`
+	Work      *validateTestAddress  `json:"work"`
+	Others    []validateTestAddress `json:"others"`
+	Emails    []string              `handy:"maxlen=2,dive,email"`
+	Ignored   string                `handy:"-"`
+	unchecked string
+}
+
+func TestValidate(t *testing.T) {
+	adult := DateTimeAsString(time.Now().AddDate(-30, 0, 0), "dd/mm/yyyy")
+	teen := DateTimeAsString(time.Now().AddDate(-15, 0, 0), "dd/mm/yyyy")
+
+	valid := validateTestPerson{
+		Name:      "Friedrich Nietzsche",
+		Email:     "fn@example.com",
+		CPF:       "529.982.247-25",
+		Birthdate: adult,
+		Age:       30,
+		Plan:      "pro",
+		Nick:      "zarathustra1",
+		Address:   validateTestAddress{Street: "Main St", Zip: "01310100"},
+		Emails:    []string{"a@b.com"},
+	}
+
+	if err := Validate(&valid); err != nil {
+		t.Fatalf("Test has failed! Expected nil, got %v", err)
+	}
+
+	invalid := valid
+	invalid.Name = "X"
+	invalid.Email = ""
+	invalid.CPF = "111.111.111-11"
+	invalid.Birthdate = teen
+	invalid.Age = 200
+	invalid.Plan = "gold"
+	invalid.Nick = "no numbers"
+	invalid.Address.Street = ""
+	invalid.Work = &validateTestAddress{Street: "A very long street name"}
+	invalid.Others = []validateTestAddress{{Street: "ok"}, {Street: ""}}
+	invalid.Emails = []string{"a@b.com", "invalid"}
+
+	err := Validate(invalid)
+
+	var ve ValidationErrors
+
+	if !errors.As(err, &ve) {
+		t.Fatalf("Test has failed! Expected ValidationErrors, got %v", err)
+	}
+
+	expected := map[string]string{
+		"name":             "personname",
+		"email":            "required",
+		"CPF":              "cpf",
+		"Birthdate":        "agemin",
+		"Age":              "max",
+		"Plan":             "oneof",
+		"Nick":             "checkstr",
+		"address.street":   "required",
+		"work.street":      "maxlen",
+		"others[1].street": "required",
+		"Emails[1]":        "email",
+	}
+
+	if len(ve) != len(expected) {
+		t.Errorf("Test has failed! Expected %d errors, got %d: %v", len(expected), len(ve), ve)
+	}
+
+	for field, rule := range expected {
+		if e, ok := ve.Field(field); !ok || e.Rule != rule {
+			t.Errorf("Test has failed! Field %s expected rule %s, got %+v", field, rule, e)
+		}
+	}
+
+	if !errors.Is(ve[6].Err, ErrCheckStrSpaceDenied) {
+		t.Errorf("Test has failed! checkstr error should wrap ErrCheckStrSpaceDenied: %v", ve[6])
+	}
+
+	msgs := ve.Messages("bra")
+
+	if msgs["email"] != "campo obrigatório" || msgs["work.street"] != "deve conter no máximo 10 caracteres" {
+		t.Errorf("Test has failed! Wrong translations: %v", msgs)
+	}
+}
+
+func TestValidationRuleRegister(t *testing.T) {
+	ValidationRuleRegister("even", func(f ValidationField) error {
+		if f.Value.Int()%2 != 0 {
+			return fmt.Errorf("%d is odd", f.Value.Int())
+		}
+
+		return nil
+	})
+
+	type sample struct {
+		N int `handy:"even"`
+		X int `handy:"nosuchrule"`
+	}
+
+	if err := Validate(struct {
+		N int `handy:"even"`
+	}{N: 3}); err == nil || err.Error() != "N: 3 is odd" {
+		t.Errorf("Test has failed! Got %v", err)
+	}
+
+	if err := Validate(sample{N: 2, X: 1}); err == nil {
+		t.Error("Test has failed! Unknown rules must return an error")
+	} else if _, ok := err.(ValidationErrors); ok {
+		t.Errorf("Test has failed! Unknown rules are not validation errors: %v", err)
+	}
+
+	if err := Validate(42); err == nil {
+		t.Error("Test has failed! Non-struct values must return an error")
+	}
+}
+
+func TestValidateBadParams(t *testing.T) {
+	tcs := []struct {
+		summary string
+		v       interface{}
+	}{
+		{"maxlen", struct {
+			S string `handy:"maxlen=abc"`
+		}{"value"}},
+		{"agemin", struct {
+			S string `handy:"agemin=x"`
+		}{"01/01/2000"}},
+		{"min", struct {
+			N int `handy:"min=one"`
+		}{1}},
+		{"checkstr", struct {
+			S string `handy:"checkstr=denyspaces|nosuchflag"`
+		}{"value"}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			err := Validate(tc.v)
+
+			var ve ValidationErrors
+
+			if !errors.Is(err, ErrValidationParam) || errors.As(err, &ve) {
+				t.Errorf("Test has failed!\n\tExpected a plain error wrapping ErrValidationParam, got %#v", err)
+			}
+		})
+	}
+}
+
+type validateTestInner struct {
+	Name string `json:"name" handy:"required"`
+}
+
+type ValidateTestExported struct {
+	Code string `handy:"required"`
+}
+
+func TestValidateEmbedded(t *testing.T) {
+	var v struct {
+		validateTestInner
+		*ValidateTestExported
+		Named validateTestInner `json:"named"`
+	}
+
+	v.ValidateTestExported = &ValidateTestExported{}
+
+	err := Validate(v)
+
+	var ve ValidationErrors
+
+	if !errors.As(err, &ve) || len(ve) != 3 {
+		t.Fatalf("Test has failed! Expected 3 errors, got %v", err)
+	}
+
+	for _, field := range []string{"name", "Code", "named.name"} {
+		if _, ok := ve.Field(field); !ok {
+			t.Errorf("Test has failed! Expected an error for %s, got %v", field, ve)
+		}
+	}
+}
+
+func TestValidateAgeInvalidDate(t *testing.T) {
+	tcs := []struct {
+		summary string
+		v       interface{}
+	}{
+		{"agemin", struct {
+			Birth string `handy:"agemin=18"`
+		}{"xx"}},
+		{"agemax with format", struct {
+			Birth string `handy:"date=dd/mm/yyyy,agemax=60"`
+		}{"xx"}},
+		{"agemax with format after", struct {
+			Birth string `handy:"agemax=60,date=dd/mm/yyyy"`
+		}{"31/02/2000"}},
+	}
+
+	expected := DateStrCheckErrMessage("", DateStrCheckErrInvalid)
+
+	for _, tc := range tcs {
+		t.Run(tc.summary, func(t *testing.T) {
+			var ve ValidationErrors
+
+			if err := Validate(tc.v); !errors.As(err, &ve) || len(ve) != 1 || ve[0].Rule != "date" || ve[0].Message("") != expected {
+				t.Errorf("Test has failed!\n\tExpected: %s, \n\tGot: %v", expected, err)
+			}
+		})
+	}
+}